@@ -30,6 +30,37 @@ type Config struct {
 	KubeConfig           string
 	ServerCrt            string
 	ServerKey            string
+	AdmissionBindAddress string
+	AdmissionPort        int
+
+	SelfSignedCert              bool
+	CertSecretName              string
+	WebhookServiceName          string
+	WebhookServiceNamespace     string
+	ValidatingWebhookConfigName string
+	MutatingWebhookConfigName   string
+	CABundleFile                string
+	RegisterWebhooks            bool
+	WebhookFailurePolicy        string
+	WebhookTimeoutSeconds       int
+	WebhookNamespaceSelector    string
+
+	DriverWorkers        int
+	LBWorkers            int
+	BackendGroupWorkers  int
+	BackendWorkers       int
+	MaxInFlightPerDriver int
+	BackendBatchWindow   time.Duration
+
+	LeaderElect                  bool
+	LeaderElectLeaseDuration     time.Duration
+	LeaderElectRenewDeadline     time.Duration
+	LeaderElectRetryPeriod       time.Duration
+	LeaderElectResourceNamespace string
+	LeaderElectResourceName      string
+
+	ReadyzWorkerStuckThreshold time.Duration
+	ReadyzRequireHealthyDriver bool
 }
 
 func NewConfig() *Config {
@@ -51,4 +82,66 @@ func (o *Config) AddFlags(fs *flag.FlagSet) {
 		"server-crt", "/etc/lbcf/server.crt", "Path to crt file for admit webhook server")
 	fs.StringVar(&o.ServerKey,
 		"server-key", "/etc/lbcf/server.key", "Path to key file for admit webhook server")
+	fs.StringVar(&o.AdmissionBindAddress,
+		"admission-bind-address", "", "the IP address on which admit webhook server listens, empty means all interfaces")
+	fs.IntVar(&o.AdmissionPort,
+		"admission-port", 443, "the port on which admit webhook server listens")
+	fs.BoolVar(&o.SelfSignedCert,
+		"self-signed-cert", false, "generate a self-signed CA and serving certificate for admit webhook server instead of "+
+			"loading --server-crt and --server-key, the certificate is stored in --cert-secret-name and rotated before it expires")
+	fs.StringVar(&o.CertSecretName,
+		"cert-secret-name", "lbcf-controller-tls", "name of the Secret storing the self-signed certificate, "+
+			"the Secret is in --webhook-service-namespace")
+	fs.StringVar(&o.WebhookServiceName,
+		"webhook-service-name", "lbcf-controller", "name of the Service exposing admit webhook server")
+	fs.StringVar(&o.WebhookServiceNamespace,
+		"webhook-service-namespace", "kube-system", "namespace of the Service exposing admit webhook server")
+	fs.StringVar(&o.ValidatingWebhookConfigName,
+		"validating-webhook-config-name", "lbcf-validate", "name of the ValidatingWebhookConfiguration of lbcf-controller")
+	fs.StringVar(&o.MutatingWebhookConfigName,
+		"mutating-webhook-config-name", "lbcf-mutate", "name of the MutatingWebhookConfiguration of lbcf-controller")
+	fs.StringVar(&o.CABundleFile,
+		"ca-bundle-file", "", "Path to the CA that signs --server-crt, it is used as caBundle when registering webhooks, "+
+			"ignored if --self-signed-cert is set")
+	fs.BoolVar(&o.RegisterWebhooks,
+		"register-webhooks", true, "create or update --validating-webhook-config-name and --mutating-webhook-config-name "+
+			"at startup according to webhooks served by admit webhook server")
+	fs.StringVar(&o.WebhookFailurePolicy,
+		"webhook-failure-policy", "Fail", "failurePolicy of registered webhooks, Fail or Ignore")
+	fs.IntVar(&o.WebhookTimeoutSeconds,
+		"webhook-timeout-seconds", 10, "timeoutSeconds of registered webhooks, between 1 and 30")
+	fs.StringVar(&o.WebhookNamespaceSelector,
+		"webhook-namespace-selector", "", "label selector of namespaces whose objects are sent to registered webhooks, "+
+			"empty means all namespaces")
+	fs.IntVar(&o.DriverWorkers,
+		"driver-workers", 1, "number of workers syncing LoadBalancerDrivers")
+	fs.IntVar(&o.LBWorkers,
+		"lb-workers", 10, "number of workers syncing LoadBalancers")
+	fs.IntVar(&o.BackendGroupWorkers,
+		"backendgroup-workers", 10, "number of workers syncing BackendGroups")
+	fs.IntVar(&o.BackendWorkers,
+		"backend-workers", 20, "number of workers syncing BackendRecords")
+	fs.IntVar(&o.MaxInFlightPerDriver,
+		"max-inflight-per-driver", 0, "maximum number of concurrent webhook calls from controllers to a driver, "+
+			"0 means unlimited")
+	fs.DurationVar(&o.BackendBatchWindow,
+		"backend-batch-window", 200*time.Millisecond, "time to wait for backends on the same load balancer to join a batch, "+
+			"only used by drivers that implement batchEnsureBackend or batchDeregisterBackend")
+	fs.BoolVar(&o.LeaderElect,
+		"leader-elect", false, "Start a leader election client and gain leadership before running controllers, "+
+			"admission webhook server is always running")
+	fs.DurationVar(&o.LeaderElectLeaseDuration,
+		"leader-elect-lease-duration", 15*time.Second, "the duration that non-leader candidates will wait before force acquiring leadership")
+	fs.DurationVar(&o.LeaderElectRenewDeadline,
+		"leader-elect-renew-deadline", 10*time.Second, "the duration that the leader will retry refreshing leadership before giving up")
+	fs.DurationVar(&o.LeaderElectRetryPeriod,
+		"leader-elect-retry-period", 2*time.Second, "the duration that clients should wait between attempting acquisition and renewal of leadership")
+	fs.StringVar(&o.LeaderElectResourceNamespace,
+		"leader-elect-resource-namespace", "kube-system", "namespace of the Lease used for leader election")
+	fs.StringVar(&o.LeaderElectResourceName,
+		"leader-elect-resource-name", "lbcf-controller", "name of the Lease used for leader election")
+	fs.DurationVar(&o.ReadyzWorkerStuckThreshold,
+		"readyz-worker-stuck-threshold", 10*time.Minute, "/readyz/controller fails if a worker has been syncing a key for longer than this")
+	fs.BoolVar(&o.ReadyzRequireHealthyDriver,
+		"readyz-require-healthy-driver", false, "/readyz/controller fails if no LoadBalancerDriver is healthy")
 }
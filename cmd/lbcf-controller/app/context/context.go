@@ -18,12 +18,16 @@
 package context
 
 import (
+	"time"
+
 	"tkestack.io/lb-controlling-framework/cmd/lbcf-controller/app/config"
 	lbcfv1beta "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
 	lbcfclient "tkestack.io/lb-controlling-framework/pkg/client-go/clientset/versioned"
 	lbcfclientset "tkestack.io/lb-controlling-framework/pkg/client-go/clientset/versioned"
 	"tkestack.io/lb-controlling-framework/pkg/client-go/informers/externalversions"
 	"tkestack.io/lb-controlling-framework/pkg/client-go/informers/externalversions/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/endpointslice"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
 
 	apicorev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -33,6 +37,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog"
@@ -53,6 +58,8 @@ func NewContext(cfg *config.Config) *Context {
 	c.PodInformer = c.K8sFactory.Core().V1().Pods()
 	c.SvcInformer = c.K8sFactory.Core().V1().Services()
 	c.NodeInformer = c.K8sFactory.Core().V1().Nodes()
+	c.EndpointSliceInformer = newEndpointSliceInformer(clientCfg, c.K8sClient, cfg.InformerResyncPeriod)
+	c.SecretGetter = util.NewSecretGetter(c.K8sClient.CoreV1())
 	c.LBInformer = c.LbcfFactory.Lbcf().V1beta1().LoadBalancers()
 	c.LBDriverInformer = c.LbcfFactory.Lbcf().V1beta1().LoadBalancerDrivers()
 	c.BGInformer = c.LbcfFactory.Lbcf().V1beta1().BackendGroups()
@@ -86,6 +93,12 @@ type Context struct {
 	BGInformer       v1beta1.BackendGroupInformer
 	BRInformer       v1beta1.BackendRecordInformer
 
+	// EndpointSliceInformer is nil if discovery.k8s.io/v1 is not served by the apiserver
+	EndpointSliceInformer endpointslice.Informer
+
+	// SecretGetter reads Secrets referenced by drivers, Secrets are not watched
+	SecretGetter util.SecretGetter
+
 	EventBroadCaster record.EventBroadcaster
 	EventRecorder    record.EventRecorder
 }
@@ -93,12 +106,59 @@ type Context struct {
 func (c *Context) Start() {
 	c.K8sFactory.Start(wait.NeverStop)
 	c.LbcfFactory.Start(wait.NeverStop)
+	if c.EndpointSliceInformer != nil {
+		go c.EndpointSliceInformer.Informer().Run(wait.NeverStop)
+	}
 	c.EventBroadCaster.StartRecordingToSink(&corev1.EventSinkImpl{Interface: c.K8sClient.CoreV1().Events("")})
 }
 
 func (c *Context) WaitForCacheSync() {
 	c.K8sFactory.WaitForCacheSync(wait.NeverStop)
 	c.LbcfFactory.WaitForCacheSync(wait.NeverStop)
+	if c.EndpointSliceInformer != nil {
+		cache.WaitForCacheSync(wait.NeverStop, c.EndpointSliceInformer.Informer().HasSynced)
+	}
+}
+
+// HasSynced returns true if all informers are synced
+func (c *Context) HasSynced() bool {
+	informers := []cache.SharedIndexInformer{
+		c.PodInformer.Informer(),
+		c.SvcInformer.Informer(),
+		c.NodeInformer.Informer(),
+		c.LBInformer.Informer(),
+		c.LBDriverInformer.Informer(),
+		c.BGInformer.Informer(),
+		c.BRInformer.Informer(),
+	}
+	if c.EndpointSliceInformer != nil {
+		informers = append(informers, c.EndpointSliceInformer.Informer())
+	}
+	for _, informer := range informers {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// newEndpointSliceInformer returns nil if discovery.k8s.io/v1 is not served,
+// BackendGroups using service mode EndpointPod or EndpointNode are not synced in that case
+func newEndpointSliceInformer(clientCfg *rest.Config, client kubernetes.Interface, resync time.Duration) endpointslice.Informer {
+	served, err := endpointslice.IsServed(client.Discovery())
+	if err != nil {
+		klog.Fatal(err)
+	}
+	if !served {
+		klog.Warningf("%s is not served, service modes EndpointPod and EndpointNode are not supported",
+			endpointslice.SchemeGroupVersion)
+		return nil
+	}
+	esClient, err := endpointslice.NewForConfig(clientCfg)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	return endpointslice.NewInformer(esClient, resync)
 }
 
 func getClientConfigOrDie(kubeConfig string) *rest.Config {
@@ -0,0 +1,135 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"tkestack.io/lb-controlling-framework/cmd/lbcf-controller/app/context"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/admission"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog"
+)
+
+// readyzCheck is a named check served on /readyz or /readyz/controller
+type readyzCheck struct {
+	name  string
+	check func() error
+}
+
+// readyzHandler serves a list of checks, the result of each check is written if any check fails or ?verbose is set
+func readyzHandler(checks []readyzCheck) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		failed := false
+		var out bytes.Buffer
+		for _, c := range checks {
+			if err := c.check(); err != nil {
+				failed = true
+				fmt.Fprintf(&out, "[-]%s failed: %v\n", c.name, err)
+			} else {
+				fmt.Fprintf(&out, "[+]%s ok\n", c.name)
+			}
+		}
+		if failed {
+			klog.V(3).Infof("readyz check failed:\n%s", out.String())
+			http.Error(w, out.String()+"readyz check failed", http.StatusServiceUnavailable)
+			return
+		}
+		if _, verbose := r.URL.Query()["verbose"]; !verbose {
+			w.Write([]byte("ok"))
+			return
+		}
+		out.WriteString("readyz check passed\n")
+		out.WriteTo(w)
+	}
+}
+
+// admissionReadyzChecks are served on /readyz, which is used as the readinessProbe.
+// Readiness decides whether a replica receives admission requests, so only checks that affect admission are included,
+// otherwise an unhealthy driver or a stuck worker would make admission webhooks reject every request.
+func admissionReadyzChecks(ctx *context.Context, admissionServer *admission.Server) []readyzCheck {
+	return []readyzCheck{
+		informerSyncCheck(ctx),
+		{
+			name:  "admission-server",
+			check: admissionServer.CheckListening,
+		},
+	}
+}
+
+// controllerReadyzChecks are served on /readyz/controller for monitoring the controllers,
+// they are not used by the readinessProbe
+func controllerReadyzChecks(ctx *context.Context,
+	lbcf *lbcfcontroller.Controller,
+	leading *int32) []readyzCheck {
+	checks := []readyzCheck{
+		informerSyncCheck(ctx),
+		{
+			name: "leader-election",
+			check: func() error {
+				// replicas not leading only serve admission webhooks
+				if atomic.LoadInt32(leading) == 1 && !lbcf.WorkersStarted() {
+					return fmt.Errorf("leading but controller workers are not started")
+				}
+				return nil
+			},
+		},
+		{
+			name: "workers",
+			check: func() error {
+				return lbcf.CheckWorkers(ctx.Cfg.ReadyzWorkerStuckThreshold)
+			},
+		},
+	}
+	if ctx.Cfg.ReadyzRequireHealthyDriver {
+		checks = append(checks, readyzCheck{
+			name: "healthy-driver",
+			check: func() error {
+				drivers, err := ctx.LBDriverInformer.Lister().List(labels.Everything())
+				if err != nil {
+					return err
+				}
+				for _, driver := range drivers {
+					if driver.DeletionTimestamp == nil && util.IsDriverHealthy(driver) {
+						return nil
+					}
+				}
+				return fmt.Errorf("none of %d LoadBalancerDrivers is healthy", len(drivers))
+			},
+		})
+	}
+	return checks
+}
+
+func informerSyncCheck(ctx *context.Context) readyzCheck {
+	return readyzCheck{
+		name: "informer-sync",
+		check: func() error {
+			if !ctx.HasSynced() {
+				return fmt.Errorf("informers are not synced")
+			}
+			return nil
+		},
+	}
+}
@@ -18,19 +18,29 @@
 package app
 
 import (
+	gocontext "context"
 	"flag"
-	"k8s.io/klog"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"strconv"
+	"sync/atomic"
 
 	"tkestack.io/lb-controlling-framework/cmd/lbcf-controller/app/config"
 	"tkestack.io/lb-controlling-framework/cmd/lbcf-controller/app/context"
 	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller"
 	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/admission"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/metrics"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog"
 )
 
 func NewServer() *cobra.Command {
@@ -41,17 +51,27 @@ func NewServer() *cobra.Command {
 
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx := context.NewContext(cfg)
-			admissionWebhookServer := admission.NewWebhookServer(ctx, cfg.ServerCrt, cfg.ServerKey)
+			admissionWebhookServer := admission.NewWebhookServer(ctx, cfg.ServerCrt, cfg.ServerKey,
+				net.JoinHostPort(cfg.AdmissionBindAddress, strconv.Itoa(cfg.AdmissionPort)))
 			lbcf := lbcfcontroller.NewController(ctx)
 
+			var leading int32
 			ctx.Start()
 			admissionWebhookServer.Start()
-			lbcf.Start()
+			if cfg.LeaderElect {
+				go runLeaderElection(ctx, lbcf, &leading)
+			} else {
+				leading = 1
+				lbcf.Start()
+			}
 
 			mux := http.NewServeMux()
 			mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
 				w.Write([]byte("ok"))
 			})
+			mux.HandleFunc("/readyz", readyzHandler(admissionReadyzChecks(ctx, admissionWebhookServer)))
+			mux.HandleFunc("/readyz/controller", readyzHandler(controllerReadyzChecks(ctx, lbcf, &leading)))
+			mux.Handle("/metrics", metrics.Handler())
 			go http.ListenAndServe(":11029", mux)
 
 			<-wait.NeverStop
@@ -67,3 +87,43 @@ func NewServer() *cobra.Command {
 	cmd.Flags().AddGoFlagSet(fs)
 	return cmd
 }
+
+// runLeaderElection blocks until leadership is lost, lbcf controller is started only after leadership is acquired
+func runLeaderElection(ctx *context.Context, lbcf *lbcfcontroller.Controller, leading *int32) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		klog.Fatalf("unable to get hostname: %v", err)
+	}
+	identity := hostname + "_" + string(uuid.NewUUID())
+	lock := &util.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Namespace: ctx.Cfg.LeaderElectResourceNamespace,
+			Name:      ctx.Cfg.LeaderElectResourceName,
+		},
+		Client: ctx.K8sClient.CoordinationV1beta1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity:      identity,
+			EventRecorder: ctx.EventRecorder,
+		},
+	}
+	leaderelection.RunOrDie(gocontext.Background(), leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: ctx.Cfg.LeaderElectLeaseDuration,
+		RenewDeadline: ctx.Cfg.LeaderElectRenewDeadline,
+		RetryPeriod:   ctx.Cfg.LeaderElectRetryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(gocontext.Context) {
+				klog.Infof("%s started leading", identity)
+				atomic.StoreInt32(leading, 1)
+				lbcf.Start()
+			},
+			OnStoppedLeading: func() {
+				// workers can not be stopped gracefully, exit and let the new leader take over
+				klog.Fatalf("%s lost leadership", identity)
+			},
+			OnNewLeader: func(leader string) {
+				klog.Infof("new leader elected: %s", leader)
+			},
+		},
+	})
+}
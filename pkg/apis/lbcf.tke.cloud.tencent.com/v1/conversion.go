@@ -0,0 +1,536 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package v1
+
+import (
+	"strings"
+
+	"tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// v1beta1 is the storage version, objects are converted between v1 and v1beta1 by the conversion webhook.
+// All conversions are lossless, so that objects can be read and written in either version.
+
+// Convert_v1beta1_LoadBalancer_To_v1_LoadBalancer converts LoadBalancer from v1beta1 to v1
+func Convert_v1beta1_LoadBalancer_To_v1_LoadBalancer(in *v1beta1.LoadBalancer, out *LoadBalancer) {
+	out.TypeMeta = metav1.TypeMeta{Kind: in.Kind, APIVersion: SchemeGroupVersion.String()}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = LoadBalancerSpec{
+		LBDriver:          in.Spec.LBDriver,
+		LBSpec:            in.Spec.LBSpec,
+		Attributes:        in.Spec.Attributes,
+		EnsurePolicy:      ensurePolicyFromV1beta1(in.Spec.EnsurePolicy),
+		AllowedNamespaces: in.Spec.AllowedNamespaces,
+		Import:            in.Spec.Import,
+		DeletionPolicy:    DeletionPolicy(in.Spec.DeletionPolicy),
+	}
+	out.Status = LoadBalancerStatus{
+		LBInfo: in.Status.LBInfo,
+	}
+	for _, c := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, Condition{
+			Type:               ConditionType(c.Type),
+			Status:             ConditionStatus(c.Status),
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             ConditionReason(c.Reason),
+			Message:            c.Message,
+		})
+	}
+}
+
+// Convert_v1_LoadBalancer_To_v1beta1_LoadBalancer converts LoadBalancer from v1 to v1beta1
+func Convert_v1_LoadBalancer_To_v1beta1_LoadBalancer(in *LoadBalancer, out *v1beta1.LoadBalancer) {
+	out.TypeMeta = metav1.TypeMeta{Kind: in.Kind, APIVersion: v1beta1.SchemeGroupVersion.String()}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = v1beta1.LoadBalancerSpec{
+		LBDriver:          in.Spec.LBDriver,
+		LBSpec:            in.Spec.LBSpec,
+		Attributes:        in.Spec.Attributes,
+		EnsurePolicy:      ensurePolicyToV1beta1(in.Spec.EnsurePolicy),
+		AllowedNamespaces: in.Spec.AllowedNamespaces,
+		Import:            in.Spec.Import,
+		DeletionPolicy:    v1beta1.DeletionPolicy(in.Spec.DeletionPolicy),
+	}
+	out.Status = v1beta1.LoadBalancerStatus{
+		LBInfo: in.Status.LBInfo,
+	}
+	for _, c := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, v1beta1.LoadBalancerCondition{
+			Type:               v1beta1.LoadBalancerConditionType(c.Type),
+			Status:             v1beta1.ConditionStatus(c.Status),
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             string(c.Reason),
+			Message:            c.Message,
+		})
+	}
+}
+
+// Convert_v1beta1_BackendGroup_To_v1_BackendGroup converts BackendGroup from v1beta1 to v1
+func Convert_v1beta1_BackendGroup_To_v1_BackendGroup(in *v1beta1.BackendGroup, out *BackendGroup) {
+	out.TypeMeta = metav1.TypeMeta{Kind: in.Kind, APIVersion: SchemeGroupVersion.String()}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = BackendGroupSpec{
+		LBName:       in.Spec.LBName,
+		LBNamespace:  in.Spec.LBNamespace,
+		Static:       in.Spec.Static,
+		Parameters:   in.Spec.Parameters,
+		EnsurePolicy: ensurePolicyFromV1beta1(in.Spec.EnsurePolicy),
+	}
+	var singlePort []string
+	if svc := in.Spec.Service; svc != nil {
+		out.Spec.Service = &ServiceBackend{
+			Name:         svc.Name,
+			NodeSelector: labelSelectorFromV1beta1(svc.NodeSelector, svc.NodeMatchExpressions),
+			Mode:         ServiceBackendMode(svc.Mode),
+		}
+		var single bool
+		out.Spec.Service.Ports, single = portsFromV1beta1(svc.Port, svc.Ports)
+		if single {
+			singlePort = append(singlePort, singlePortService)
+		}
+	}
+	if pods := in.Spec.Pods; pods != nil {
+		out.Spec.Pods = &PodBackend{
+			ByName:        pods.ByName,
+			ReadinessGate: pods.ReadinessGate,
+		}
+		var single bool
+		out.Spec.Pods.Ports, single = portsFromV1beta1(pods.Port, pods.Ports)
+		if single {
+			singlePort = append(singlePort, singlePortPods)
+		}
+		if pods.ByLabel != nil {
+			out.Spec.Pods.Selector = &metav1.LabelSelector{
+				MatchLabels:      pods.ByLabel.Selector,
+				MatchExpressions: pods.ByLabel.MatchExpressions,
+			}
+			out.Spec.Pods.Except = pods.ByLabel.Except
+		}
+		if pods.Drain != nil {
+			out.Spec.Pods.Drain = &DrainConfig{
+				DrainPeriod: metav1.Duration{Duration: pods.Drain.DrainPeriod.Duration},
+			}
+		}
+		if pods.Weight != nil {
+			out.Spec.Pods.Weight = &WeightConfig{
+				Annotation:    pods.Weight.Annotation,
+				DefaultWeight: pods.Weight.DefaultWeight,
+			}
+		}
+	}
+	if nodes := in.Spec.Nodes; nodes != nil {
+		out.Spec.Nodes = &NodeBackend{
+			Port:     PortSelector(nodes.Port),
+			Selector: labelSelectorFromV1beta1(nodes.Selector, nodes.MatchExpressions),
+		}
+	}
+	if len(singlePort) > 0 {
+		out.Annotations = copyAnnotations(in.Annotations)
+		out.Annotations[AnnotationV1beta1SinglePort] = strings.Join(singlePort, ",")
+	}
+	out.Status = BackendGroupStatus{
+		Backends:           in.Status.Backends,
+		RegisteredBackends: in.Status.RegisteredBackends,
+	}
+	for _, p := range in.Status.Ports {
+		out.Status.Ports = append(out.Status.Ports, BackendGroupPortStatus{
+			Port:               PortSelector(p.Port),
+			Backends:           p.Backends,
+			RegisteredBackends: p.RegisteredBackends,
+		})
+	}
+}
+
+// Convert_v1_BackendGroup_To_v1beta1_BackendGroup converts BackendGroup from v1 to v1beta1
+func Convert_v1_BackendGroup_To_v1beta1_BackendGroup(in *BackendGroup, out *v1beta1.BackendGroup) {
+	out.TypeMeta = metav1.TypeMeta{Kind: in.Kind, APIVersion: v1beta1.SchemeGroupVersion.String()}
+	out.ObjectMeta = in.ObjectMeta
+	singlePort := sets.NewString()
+	if value, ok := in.Annotations[AnnotationV1beta1SinglePort]; ok {
+		singlePort.Insert(strings.Split(value, ",")...)
+		out.Annotations = copyAnnotations(in.Annotations)
+		delete(out.Annotations, AnnotationV1beta1SinglePort)
+		if len(out.Annotations) == 0 {
+			out.Annotations = nil
+		}
+	}
+	out.Spec = v1beta1.BackendGroupSpec{
+		LBName:       in.Spec.LBName,
+		LBNamespace:  in.Spec.LBNamespace,
+		Static:       in.Spec.Static,
+		Parameters:   in.Spec.Parameters,
+		EnsurePolicy: ensurePolicyToV1beta1(in.Spec.EnsurePolicy),
+	}
+	if svc := in.Spec.Service; svc != nil {
+		out.Spec.Service = &v1beta1.ServiceBackend{
+			Name: svc.Name,
+			Mode: v1beta1.ServiceBackendMode(svc.Mode),
+		}
+		out.Spec.Service.Port, out.Spec.Service.Ports = portsToV1beta1(svc.Ports, singlePort.Has(singlePortService))
+		if svc.NodeSelector != nil {
+			out.Spec.Service.NodeSelector = svc.NodeSelector.MatchLabels
+			out.Spec.Service.NodeMatchExpressions = svc.NodeSelector.MatchExpressions
+		}
+	}
+	if pods := in.Spec.Pods; pods != nil {
+		out.Spec.Pods = &v1beta1.PodBackend{
+			ByName:        pods.ByName,
+			ReadinessGate: pods.ReadinessGate,
+		}
+		out.Spec.Pods.Port, out.Spec.Pods.Ports = portsToV1beta1(pods.Ports, singlePort.Has(singlePortPods))
+		if pods.Selector != nil || pods.Except != nil {
+			out.Spec.Pods.ByLabel = &v1beta1.SelectPodByLabel{
+				Except: pods.Except,
+			}
+			if pods.Selector != nil {
+				out.Spec.Pods.ByLabel.Selector = pods.Selector.MatchLabels
+				out.Spec.Pods.ByLabel.MatchExpressions = pods.Selector.MatchExpressions
+			}
+		}
+		if pods.Drain != nil {
+			out.Spec.Pods.Drain = &v1beta1.DrainConfig{
+				DrainPeriod: v1beta1.Duration{Duration: pods.Drain.DrainPeriod.Duration},
+			}
+		}
+		if pods.Weight != nil {
+			out.Spec.Pods.Weight = &v1beta1.WeightConfig{
+				Annotation:    pods.Weight.Annotation,
+				DefaultWeight: pods.Weight.DefaultWeight,
+			}
+		}
+	}
+	if nodes := in.Spec.Nodes; nodes != nil {
+		out.Spec.Nodes = &v1beta1.NodeBackend{
+			Port: v1beta1.PortSelector(nodes.Port),
+		}
+		if nodes.Selector != nil {
+			out.Spec.Nodes.Selector = nodes.Selector.MatchLabels
+			out.Spec.Nodes.MatchExpressions = nodes.Selector.MatchExpressions
+		}
+	}
+	out.Status = v1beta1.BackendGroupStatus{
+		Backends:           in.Status.Backends,
+		RegisteredBackends: in.Status.RegisteredBackends,
+	}
+	for _, p := range in.Status.Ports {
+		out.Status.Ports = append(out.Status.Ports, v1beta1.BackendGroupPortStatus{
+			Port:               v1beta1.PortSelector(p.Port),
+			Backends:           p.Backends,
+			RegisteredBackends: p.RegisteredBackends,
+		})
+	}
+}
+
+// Convert_v1beta1_LoadBalancerDriver_To_v1_LoadBalancerDriver converts LoadBalancerDriver from v1beta1 to v1
+func Convert_v1beta1_LoadBalancerDriver_To_v1_LoadBalancerDriver(in *v1beta1.LoadBalancerDriver, out *LoadBalancerDriver) {
+	out.TypeMeta = metav1.TypeMeta{Kind: in.Kind, APIVersion: SchemeGroupVersion.String()}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = LoadBalancerDriverSpec{
+		DriverType:      DriverType(in.Spec.DriverType),
+		URL:             in.Spec.Url,
+		ProtocolVersion: ProtocolVersion(in.Spec.ProtocolVersion),
+	}
+	for _, wh := range in.Spec.Webhooks {
+		out.Spec.Webhooks = append(out.Spec.Webhooks, WebhookConfig{
+			Name:    wh.Name,
+			Timeout: metav1.Duration{Duration: wh.Timeout.Duration},
+		})
+	}
+	if cc := in.Spec.ClientConfig; cc != nil {
+		out.Spec.ClientConfig = &DriverClientConfig{
+			CASecret:         secretReferenceFromV1beta1(cc.CASecret),
+			ClientCertSecret: secretReferenceFromV1beta1(cc.ClientCertSecret),
+			TokenSecret:      secretReferenceFromV1beta1(cc.TokenSecret),
+		}
+	}
+	if hc := in.Spec.HealthCheck; hc != nil {
+		out.Spec.HealthCheck = &DriverHealthCheck{
+			Path:    hc.Path,
+			Period:  durationFromV1beta1(hc.Period),
+			Timeout: durationFromV1beta1(hc.Timeout),
+		}
+	}
+	out.Status = LoadBalancerDriverStatus{
+		LastProbeLatency: durationFromV1beta1(in.Status.LastProbeLatency),
+	}
+	for _, c := range in.Status.Conditions {
+		cond := Condition{
+			Type:               ConditionType(c.Type),
+			Status:             ConditionStatus(c.Status),
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             ConditionReason(c.Reason),
+			Message:            c.Message,
+		}
+		if !c.LastProbeTime.IsZero() {
+			t := c.LastProbeTime
+			cond.LastProbeTime = &t
+		}
+		out.Status.Conditions = append(out.Status.Conditions, cond)
+	}
+}
+
+// Convert_v1_LoadBalancerDriver_To_v1beta1_LoadBalancerDriver converts LoadBalancerDriver from v1 to v1beta1
+func Convert_v1_LoadBalancerDriver_To_v1beta1_LoadBalancerDriver(in *LoadBalancerDriver, out *v1beta1.LoadBalancerDriver) {
+	out.TypeMeta = metav1.TypeMeta{Kind: in.Kind, APIVersion: v1beta1.SchemeGroupVersion.String()}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = v1beta1.LoadBalancerDriverSpec{
+		DriverType:      string(in.Spec.DriverType),
+		Url:             in.Spec.URL,
+		ProtocolVersion: v1beta1.ProtocolVersion(in.Spec.ProtocolVersion),
+	}
+	for _, wh := range in.Spec.Webhooks {
+		out.Spec.Webhooks = append(out.Spec.Webhooks, v1beta1.WebhookConfig{
+			Name:    wh.Name,
+			Timeout: v1beta1.Duration{Duration: wh.Timeout.Duration},
+		})
+	}
+	if cc := in.Spec.ClientConfig; cc != nil {
+		out.Spec.ClientConfig = &v1beta1.DriverClientConfig{
+			CASecret:         secretReferenceToV1beta1(cc.CASecret),
+			ClientCertSecret: secretReferenceToV1beta1(cc.ClientCertSecret),
+			TokenSecret:      secretReferenceToV1beta1(cc.TokenSecret),
+		}
+	}
+	if hc := in.Spec.HealthCheck; hc != nil {
+		out.Spec.HealthCheck = &v1beta1.DriverHealthCheck{
+			Path:    hc.Path,
+			Period:  durationToV1beta1(hc.Period),
+			Timeout: durationToV1beta1(hc.Timeout),
+		}
+	}
+	out.Status = v1beta1.LoadBalancerDriverStatus{
+		LastProbeLatency: durationToV1beta1(in.Status.LastProbeLatency),
+	}
+	for _, c := range in.Status.Conditions {
+		cond := v1beta1.LoadBalancerDriverCondition{
+			Type:               v1beta1.LoadBalancerDriverConditionType(c.Type),
+			Status:             v1beta1.ConditionStatus(c.Status),
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             string(c.Reason),
+			Message:            c.Message,
+		}
+		if c.LastProbeTime != nil {
+			cond.LastProbeTime = *c.LastProbeTime
+		}
+		out.Status.Conditions = append(out.Status.Conditions, cond)
+	}
+}
+
+// Convert_v1beta1_BackendRecord_To_v1_BackendRecord converts BackendRecord from v1beta1 to v1
+func Convert_v1beta1_BackendRecord_To_v1_BackendRecord(in *v1beta1.BackendRecord, out *BackendRecord) {
+	out.TypeMeta = metav1.TypeMeta{Kind: in.Kind, APIVersion: SchemeGroupVersion.String()}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = BackendRecordSpec{
+		LBName:       in.Spec.LBName,
+		LBDriver:     in.Spec.LBDriver,
+		LBInfo:       in.Spec.LBInfo,
+		LBAttributes: in.Spec.LBAttributes,
+		Parameters:   in.Spec.Parameters,
+		LBNamespace:  in.Spec.LBNamespace,
+		StaticAddr:   in.Spec.StaticAddr,
+		EnsurePolicy: ensurePolicyFromV1beta1(in.Spec.EnsurePolicy),
+		Weight:       in.Spec.Weight,
+	}
+	if pod := in.Spec.PodBackendInfo; pod != nil {
+		out.Spec.PodBackendInfo = &PodBackendRecord{
+			Name:          pod.Name,
+			Port:          PortSelector(pod.Port),
+			DrainPeriod:   durationFromV1beta1(pod.DrainPeriod),
+			ReadinessGate: pod.ReadinessGate,
+		}
+	}
+	if svc := in.Spec.ServiceBackendInfo; svc != nil {
+		out.Spec.ServiceBackendInfo = &ServiceBackendRecord{
+			Name:     svc.Name,
+			Port:     PortSelector(svc.Port),
+			NodePort: svc.NodePort,
+			NodeName: svc.NodeName,
+		}
+	}
+	if node := in.Spec.NodeBackendInfo; node != nil {
+		out.Spec.NodeBackendInfo = &NodeBackendRecord{
+			Name: node.Name,
+			Port: PortSelector(node.Port),
+		}
+	}
+	out.Status = BackendRecordStatus{
+		BackendAddr:  in.Status.BackendAddr,
+		InjectedInfo: in.Status.InjectedInfo,
+	}
+	for _, c := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, Condition{
+			Type:               ConditionType(c.Type),
+			Status:             ConditionStatus(c.Status),
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             ConditionReason(c.Reason),
+			Message:            c.Message,
+		})
+	}
+}
+
+// Convert_v1_BackendRecord_To_v1beta1_BackendRecord converts BackendRecord from v1 to v1beta1
+func Convert_v1_BackendRecord_To_v1beta1_BackendRecord(in *BackendRecord, out *v1beta1.BackendRecord) {
+	out.TypeMeta = metav1.TypeMeta{Kind: in.Kind, APIVersion: v1beta1.SchemeGroupVersion.String()}
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = v1beta1.BackendRecordSpec{
+		LBName:       in.Spec.LBName,
+		LBDriver:     in.Spec.LBDriver,
+		LBInfo:       in.Spec.LBInfo,
+		LBAttributes: in.Spec.LBAttributes,
+		Parameters:   in.Spec.Parameters,
+		LBNamespace:  in.Spec.LBNamespace,
+		StaticAddr:   in.Spec.StaticAddr,
+		EnsurePolicy: ensurePolicyToV1beta1(in.Spec.EnsurePolicy),
+		Weight:       in.Spec.Weight,
+	}
+	if pod := in.Spec.PodBackendInfo; pod != nil {
+		out.Spec.PodBackendInfo = &v1beta1.PodBackendRecord{
+			Name:          pod.Name,
+			Port:          v1beta1.PortSelector(pod.Port),
+			DrainPeriod:   durationToV1beta1(pod.DrainPeriod),
+			ReadinessGate: pod.ReadinessGate,
+		}
+	}
+	if svc := in.Spec.ServiceBackendInfo; svc != nil {
+		out.Spec.ServiceBackendInfo = &v1beta1.ServiceBackendRecord{
+			Name:     svc.Name,
+			Port:     v1beta1.PortSelector(svc.Port),
+			NodePort: svc.NodePort,
+			NodeName: svc.NodeName,
+		}
+	}
+	if node := in.Spec.NodeBackendInfo; node != nil {
+		out.Spec.NodeBackendInfo = &v1beta1.NodeBackendRecord{
+			Name: node.Name,
+			Port: v1beta1.PortSelector(node.Port),
+		}
+	}
+	out.Status = v1beta1.BackendRecordStatus{
+		BackendAddr:  in.Status.BackendAddr,
+		InjectedInfo: in.Status.InjectedInfo,
+	}
+	for _, c := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, v1beta1.BackendRecordCondition{
+			Type:               v1beta1.BackendRecordConditionType(c.Type),
+			Status:             v1beta1.ConditionStatus(c.Status),
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             string(c.Reason),
+			Message:            c.Message,
+		})
+	}
+}
+
+func ensurePolicyFromV1beta1(in *v1beta1.EnsurePolicyConfig) *EnsurePolicyConfig {
+	if in == nil {
+		return nil
+	}
+	return &EnsurePolicyConfig{
+		Policy:    EnsurePolicyType(in.Policy),
+		MinPeriod: durationFromV1beta1(in.MinPeriod),
+	}
+}
+
+func ensurePolicyToV1beta1(in *EnsurePolicyConfig) *v1beta1.EnsurePolicyConfig {
+	if in == nil {
+		return nil
+	}
+	return &v1beta1.EnsurePolicyConfig{
+		Policy:    v1beta1.EnsurePolicyType(in.Policy),
+		MinPeriod: durationToV1beta1(in.MinPeriod),
+	}
+}
+
+func durationFromV1beta1(in *v1beta1.Duration) *metav1.Duration {
+	if in == nil {
+		return nil
+	}
+	return &metav1.Duration{Duration: in.Duration}
+}
+
+func durationToV1beta1(in *metav1.Duration) *v1beta1.Duration {
+	if in == nil {
+		return nil
+	}
+	return &v1beta1.Duration{Duration: in.Duration}
+}
+
+// portsFromV1beta1 merges port and ports of v1beta1 into ports of v1, single is true if port is specified
+func portsFromV1beta1(port v1beta1.PortSelector, ports []v1beta1.PortSelector) (out []PortSelector, single bool) {
+	if port != (v1beta1.PortSelector{}) {
+		out = append(out, PortSelector(port))
+		single = true
+	}
+	for _, p := range ports {
+		out = append(out, PortSelector(p))
+	}
+	return out, single
+}
+
+// portsToV1beta1 converts ports of v1 to port of v1beta1 if the object is converted from a v1beta1 object
+// that specifies port, otherwise ports of v1beta1 is used
+func portsToV1beta1(in []PortSelector, single bool) (port v1beta1.PortSelector, ports []v1beta1.PortSelector) {
+	if single && len(in) == 1 {
+		return v1beta1.PortSelector(in[0]), nil
+	}
+	if in == nil {
+		return port, nil
+	}
+	ports = make([]v1beta1.PortSelector, 0, len(in))
+	for _, p := range in {
+		ports = append(ports, v1beta1.PortSelector(p))
+	}
+	return port, ports
+}
+
+func copyAnnotations(in map[string]string) map[string]string {
+	out := make(map[string]string, len(in)+1)
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+// labelSelectorFromV1beta1 returns nil if neither labels nor expressions are specified
+func labelSelectorFromV1beta1(matchLabels map[string]string,
+	exprs []metav1.LabelSelectorRequirement) *metav1.LabelSelector {
+	if matchLabels == nil && exprs == nil {
+		return nil
+	}
+	return &metav1.LabelSelector{
+		MatchLabels:      matchLabels,
+		MatchExpressions: exprs,
+	}
+}
+
+func secretReferenceFromV1beta1(name string) *corev1.LocalObjectReference {
+	if name == "" {
+		return nil
+	}
+	return &corev1.LocalObjectReference{Name: name}
+}
+
+func secretReferenceToV1beta1(ref *corev1.LocalObjectReference) string {
+	if ref == nil {
+		return ""
+	}
+	return ref.Name
+}
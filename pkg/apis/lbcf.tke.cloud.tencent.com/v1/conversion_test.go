@@ -0,0 +1,369 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package v1
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	testTime   = metav1.NewTime(time.Unix(1559184146, 0))
+	testWeight = int32(10)
+	testAddr   = "1.1.1.1:80"
+)
+
+func testObjectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       "kube-system",
+		UID:             "2ba9b2a8-82c7-11e9-b3e1-525400d96a00",
+		ResourceVersion: "100",
+		Labels:          map[string]string{"app": "test"},
+		Finalizers:      []string{"lbcf.tke.cloud.tencent.com/delete-load-loadbalancer"},
+	}
+}
+
+// jsonRoundTrip simulates the conversion webhook, which sends and receives objects in json
+func jsonRoundTrip(t *testing.T, in interface{}, out interface{}) {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+}
+
+func TestLoadBalancerRoundTrip(t *testing.T) {
+	in := &v1beta1.LoadBalancer{
+		TypeMeta:   metav1.TypeMeta{Kind: "LoadBalancer", APIVersion: v1beta1.ApiVersion},
+		ObjectMeta: testObjectMeta("test-lb"),
+		Spec: v1beta1.LoadBalancerSpec{
+			LBDriver:   "lbcf-test-driver",
+			LBSpec:     map[string]string{"vpcID": "vpc-1"},
+			Attributes: map[string]string{"name": "test"},
+			EnsurePolicy: &v1beta1.EnsurePolicyConfig{
+				Policy:    v1beta1.PolicyAlways,
+				MinPeriod: &v1beta1.Duration{Duration: time.Minute},
+			},
+			AllowedNamespaces: []string{"*"},
+			Import:            true,
+			DeletionPolicy:    v1beta1.DeletionPolicyRetain,
+		},
+		Status: v1beta1.LoadBalancerStatus{
+			LBInfo: map[string]string{"lbID": "lb-1"},
+			Conditions: []v1beta1.LoadBalancerCondition{
+				{
+					Type:               v1beta1.LBCreated,
+					Status:             v1beta1.ConditionTrue,
+					LastTransitionTime: testTime,
+					Reason:             "Created",
+					Message:            "created",
+				},
+			},
+		},
+	}
+	converted, restored, out := &LoadBalancer{}, &LoadBalancer{}, &v1beta1.LoadBalancer{}
+	Convert_v1beta1_LoadBalancer_To_v1_LoadBalancer(in, converted)
+	if converted.APIVersion != ApiVersion {
+		t.Errorf("expect apiVersion %s, got %s", ApiVersion, converted.APIVersion)
+	}
+	jsonRoundTrip(t, converted, restored)
+	Convert_v1_LoadBalancer_To_v1beta1_LoadBalancer(restored, out)
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round trip changed LoadBalancer\nexpect: %+v\ngot:    %+v", in, out)
+	}
+}
+
+func TestBackendGroupRoundTrip(t *testing.T) {
+	port80 := v1beta1.PortSelector{PortNumber: 80, Protocol: "TCP"}
+	port443 := v1beta1.PortSelector{PortNumber: 443, Protocol: "TCP"}
+	cases := map[string]v1beta1.BackendGroupSpec{
+		"service-port": {
+			LBName: "test-lb",
+			Service: &v1beta1.ServiceBackend{
+				Name:                 "svc",
+				Port:                 port80,
+				NodeSelector:         map[string]string{"role": "lb"},
+				NodeMatchExpressions: []metav1.LabelSelectorRequirement{{Key: "zone", Operator: metav1.LabelSelectorOpExists}},
+				Mode:                 v1beta1.ServiceModeEndpointNode,
+			},
+		},
+		"service-ports": {
+			LBName:      "test-lb",
+			LBNamespace: "default",
+			Service: &v1beta1.ServiceBackend{
+				Name:  "svc",
+				Ports: []v1beta1.PortSelector{port80, port443},
+			},
+		},
+		"pods-port": {
+			LBName: "test-lb",
+			Pods: &v1beta1.PodBackend{
+				Port: port80,
+				ByLabel: &v1beta1.SelectPodByLabel{
+					Selector:         map[string]string{"app": "nginx"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "zone", Operator: metav1.LabelSelectorOpExists}},
+					Except:           []string{"nginx-0"},
+				},
+				Drain:         &v1beta1.DrainConfig{DrainPeriod: v1beta1.Duration{Duration: 10 * time.Second}},
+				ReadinessGate: true,
+				Weight:        &v1beta1.WeightConfig{Annotation: "weight", DefaultWeight: &testWeight},
+			},
+			Parameters: map[string]string{"weight": "10"},
+			EnsurePolicy: &v1beta1.EnsurePolicyConfig{
+				Policy: v1beta1.PolicyIfNotSucc,
+			},
+		},
+		"pods-single-ports": {
+			LBName: "test-lb",
+			Pods: &v1beta1.PodBackend{
+				Ports:  []v1beta1.PortSelector{port80},
+				ByName: []string{"nginx-0", "nginx-1"},
+			},
+		},
+		// rejected by validation, but conversion must keep port of each backend separately
+		"service-port-pods-ports": {
+			LBName: "test-lb",
+			Service: &v1beta1.ServiceBackend{
+				Name: "svc",
+				Port: port80,
+			},
+			Pods: &v1beta1.PodBackend{
+				Ports:  []v1beta1.PortSelector{port443},
+				ByName: []string{"nginx-0"},
+			},
+		},
+		"nodes": {
+			LBName: "test-lb",
+			Nodes: &v1beta1.NodeBackend{
+				Port:     port80,
+				Selector: map[string]string{"role": "lb"},
+			},
+		},
+		"static": {
+			LBName: "test-lb",
+			Static: []string{testAddr},
+		},
+	}
+	for name, spec := range cases {
+		in := &v1beta1.BackendGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "BackendGroup", APIVersion: v1beta1.ApiVersion},
+			ObjectMeta: testObjectMeta("test-bg"),
+			Spec:       spec,
+			Status: v1beta1.BackendGroupStatus{
+				Backends:           2,
+				RegisteredBackends: 1,
+				Ports: []v1beta1.BackendGroupPortStatus{
+					{Port: port80, Backends: 2, RegisteredBackends: 1},
+				},
+			},
+		}
+		converted, restored, out := &BackendGroup{}, &BackendGroup{}, &v1beta1.BackendGroup{}
+		Convert_v1beta1_BackendGroup_To_v1_BackendGroup(in, converted)
+		jsonRoundTrip(t, converted, restored)
+		Convert_v1_BackendGroup_To_v1beta1_BackendGroup(restored, out)
+		if !reflect.DeepEqual(in, out) {
+			t.Errorf("case %s: round trip changed BackendGroup\nexpect: %+v\ngot:    %+v", name, in.Spec, out.Spec)
+		}
+		if _, ok := in.Annotations[AnnotationV1beta1SinglePort]; ok {
+			t.Errorf("case %s: annotations of the original object must not be modified", name)
+		}
+	}
+}
+
+func TestBackendGroupPortsFromV1(t *testing.T) {
+	in := &BackendGroup{
+		ObjectMeta: testObjectMeta("test-bg"),
+		Spec: BackendGroupSpec{
+			LBName: "test-lb",
+			Pods: &PodBackend{
+				Ports:    []PortSelector{{PortNumber: 80}},
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+			},
+		},
+	}
+	mid, out := &v1beta1.BackendGroup{}, &BackendGroup{}
+	Convert_v1_BackendGroup_To_v1beta1_BackendGroup(in, mid)
+	if mid.Spec.Pods.Port != (v1beta1.PortSelector{}) || len(mid.Spec.Pods.Ports) != 1 {
+		t.Fatalf("expect ports to be converted to ports, got %+v", mid.Spec.Pods)
+	}
+	Convert_v1beta1_BackendGroup_To_v1_BackendGroup(mid, out)
+	out.TypeMeta = in.TypeMeta
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round trip changed BackendGroup\nexpect: %+v\ngot:    %+v", in, out)
+	}
+
+	// annotation is ignored if ports is updated in v1
+	in.Annotations = map[string]string{AnnotationV1beta1SinglePort: singlePortPods}
+	in.Spec.Pods.Ports = append(in.Spec.Pods.Ports, PortSelector{PortNumber: 443})
+	mid = &v1beta1.BackendGroup{}
+	Convert_v1_BackendGroup_To_v1beta1_BackendGroup(in, mid)
+	if len(mid.Spec.Pods.Ports) != 2 || mid.Annotations != nil {
+		t.Errorf("expect 2 ports without annotation, got %+v, annotations %v", mid.Spec.Pods, mid.Annotations)
+	}
+}
+
+func TestBackendGroupSinglePortAnnotation(t *testing.T) {
+	in := &v1beta1.BackendGroup{
+		ObjectMeta: testObjectMeta("test-bg"),
+		Spec: v1beta1.BackendGroupSpec{
+			LBName: "test-lb",
+			Service: &v1beta1.ServiceBackend{
+				Name:  "svc",
+				Ports: []v1beta1.PortSelector{{PortNumber: 80}},
+			},
+			Pods: &v1beta1.PodBackend{
+				Port:   v1beta1.PortSelector{PortNumber: 80},
+				ByName: []string{"nginx-0"},
+			},
+		},
+	}
+	out := &BackendGroup{}
+	Convert_v1beta1_BackendGroup_To_v1_BackendGroup(in, out)
+	if value := out.Annotations[AnnotationV1beta1SinglePort]; value != singlePortPods {
+		t.Fatalf("expect annotation %q, got %q", singlePortPods, value)
+	}
+
+	// the annotation of pods does not turn ports of service into port
+	restored := &v1beta1.BackendGroup{}
+	Convert_v1_BackendGroup_To_v1beta1_BackendGroup(out, restored)
+	if restored.Spec.Service.Port != (v1beta1.PortSelector{}) || len(restored.Spec.Service.Ports) != 1 {
+		t.Errorf("expect service ports to be kept, got %+v", restored.Spec.Service)
+	}
+	if restored.Spec.Pods.Port.PortNumber != 80 || restored.Spec.Pods.Ports != nil {
+		t.Errorf("expect pods port to be kept, got %+v", restored.Spec.Pods)
+	}
+}
+
+func TestLoadBalancerDriverRoundTrip(t *testing.T) {
+	in := &v1beta1.LoadBalancerDriver{
+		TypeMeta:   metav1.TypeMeta{Kind: "LoadBalancerDriver", APIVersion: v1beta1.ApiVersion},
+		ObjectMeta: testObjectMeta("lbcf-test-driver"),
+		Spec: v1beta1.LoadBalancerDriverSpec{
+			DriverType:      string(v1beta1.WebhookDriver),
+			Url:             "https://driver.kube-system.svc",
+			ProtocolVersion: v1beta1.ProtocolVersionV2,
+			Webhooks: []v1beta1.WebhookConfig{
+				{Name: "ensureBackend", Timeout: v1beta1.Duration{Duration: 10 * time.Second}},
+			},
+			ClientConfig: &v1beta1.DriverClientConfig{
+				CASecret:    "driver-ca",
+				TokenSecret: "driver-token",
+			},
+			HealthCheck: &v1beta1.DriverHealthCheck{
+				Path:   "/healthz",
+				Period: &v1beta1.Duration{Duration: 30 * time.Second},
+			},
+		},
+		Status: v1beta1.LoadBalancerDriverStatus{
+			Conditions: []v1beta1.LoadBalancerDriverCondition{
+				{
+					Type:               v1beta1.DriverAccepted,
+					Status:             v1beta1.ConditionTrue,
+					LastTransitionTime: testTime,
+				},
+				{
+					Type:               v1beta1.DriverHealthy,
+					Status:             v1beta1.ConditionFalse,
+					LastProbeTime:      testTime,
+					LastTransitionTime: testTime,
+					Reason:             "ProbeFailed",
+					Message:            "connection refused",
+				},
+			},
+			LastProbeLatency: &v1beta1.Duration{Duration: time.Millisecond},
+		},
+	}
+	converted, restored, out := &LoadBalancerDriver{}, &LoadBalancerDriver{}, &v1beta1.LoadBalancerDriver{}
+	Convert_v1beta1_LoadBalancerDriver_To_v1_LoadBalancerDriver(in, converted)
+	jsonRoundTrip(t, converted, restored)
+	Convert_v1_LoadBalancerDriver_To_v1beta1_LoadBalancerDriver(restored, out)
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round trip changed LoadBalancerDriver\nexpect: %+v\ngot:    %+v", in, out)
+	}
+}
+
+func TestBackendRecordRoundTrip(t *testing.T) {
+	port := v1beta1.PortSelector{PortNumber: 80, Protocol: "TCP"}
+	cases := map[string]v1beta1.BackendRecordSpec{
+		"pod": {
+			PodBackendInfo: &v1beta1.PodBackendRecord{
+				Name:          "nginx-0",
+				Port:          port,
+				DrainPeriod:   &v1beta1.Duration{Duration: 10 * time.Second},
+				ReadinessGate: true,
+			},
+			Weight: &testWeight,
+		},
+		"service": {
+			ServiceBackendInfo: &v1beta1.ServiceBackendRecord{
+				Name:     "svc",
+				Port:     port,
+				NodePort: 30080,
+				NodeName: "node-1",
+			},
+			EnsurePolicy: &v1beta1.EnsurePolicyConfig{Policy: v1beta1.PolicyIfNotSucc},
+		},
+		"node": {
+			NodeBackendInfo: &v1beta1.NodeBackendRecord{Name: "node-1", Port: port},
+		},
+		"static": {
+			StaticAddr: &testAddr,
+		},
+	}
+	for name, spec := range cases {
+		spec.LBName = "test-lb"
+		spec.LBDriver = "lbcf-test-driver"
+		spec.LBInfo = map[string]string{"lbID": "lb-1"}
+		spec.LBAttributes = map[string]string{"name": "test"}
+		spec.Parameters = map[string]string{"weight": "10"}
+		spec.LBNamespace = "kube-system"
+		in := &v1beta1.BackendRecord{
+			TypeMeta:   metav1.TypeMeta{Kind: "BackendRecord", APIVersion: v1beta1.ApiVersion},
+			ObjectMeta: testObjectMeta("test-record"),
+			Spec:       spec,
+			Status: v1beta1.BackendRecordStatus{
+				BackendAddr:  testAddr,
+				InjectedInfo: map[string]string{"instanceID": "ins-1"},
+				Conditions: []v1beta1.BackendRecordCondition{
+					{
+						Type:               v1beta1.BackendRegistered,
+						Status:             v1beta1.ConditionTrue,
+						LastTransitionTime: testTime,
+						Message:            "registered",
+					},
+				},
+			},
+		}
+		converted, restored, out := &BackendRecord{}, &BackendRecord{}, &v1beta1.BackendRecord{}
+		Convert_v1beta1_BackendRecord_To_v1_BackendRecord(in, converted)
+		jsonRoundTrip(t, converted, restored)
+		Convert_v1_BackendRecord_To_v1beta1_BackendRecord(restored, out)
+		if !reflect.DeepEqual(in, out) {
+			t.Errorf("case %s: round trip changed BackendRecord\nexpect: %+v\ngot:    %+v", name, in, out)
+		}
+	}
+}
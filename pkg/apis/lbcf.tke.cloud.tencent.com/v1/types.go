@@ -0,0 +1,459 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApiVersion is the apiVersion of objects in this package
+const ApiVersion = "lbcf.tke.cloud.tencent.com/v1"
+
+// AnnotationV1beta1SinglePort is added to BackendGroups converted from v1beta1 objects that specify port instead of
+// ports, so that they are converted back to v1beta1 without changes.
+// The value is a comma-separated list of the backends using port, i.e. "service" or "pods".
+// The annotation is reserved for conversion, it is never stored and is rejected if set in v1beta1.
+const AnnotationV1beta1SinglePort = "lbcf.tke.cloud.tencent.com/v1beta1-single-port"
+
+const (
+	singlePortService = "service"
+	singlePortPods    = "pods"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LoadBalancer is a top-level type.
+type LoadBalancer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LoadBalancerSpec   `json:"spec"`
+	Status LoadBalancerStatus `json:"status,omitempty"`
+}
+
+type LoadBalancerSpec struct {
+	LBDriver string            `json:"lbDriver"`
+	LBSpec   map[string]string `json:"lbSpec"`
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// +optional
+	EnsurePolicy *EnsurePolicyConfig `json:"ensurePolicy,omitempty"`
+	// AllowedNamespaces are namespaces whose BackendGroups are allowed to use this LoadBalancer,
+	// "*" allows all namespaces. BackendGroups in the same namespace are always allowed.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// Import adopts an existing load balancer by calling webhook importLoadBalancer instead of createLoadBalancer
+	// +optional
+	Import bool `json:"import,omitempty"`
+	// DeletionPolicy defaults to Retain for imported load balancers, and Delete for others
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DeletionPolicy determines what happens to the load balancer when a LoadBalancer is deleted
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete calls webhook deleteLoadBalancer before the LoadBalancer is deleted
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain deletes the LoadBalancer without calling webhook deleteLoadBalancer
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+type LoadBalancerStatus struct {
+	// +optional
+	LBInfo map[string]string `json:"lbInfo,omitempty"`
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LoadBalancerList is a top-level list type.
+type LoadBalancerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []LoadBalancer `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackendGroup is a top-level type.
+type BackendGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackendGroupSpec   `json:"spec"`
+	Status BackendGroupStatus `json:"status,omitempty"`
+}
+
+type BackendGroupSpec struct {
+	LBName string `json:"lbName"`
+	// LBNamespace is the namespace of the LoadBalancer, defaults to the namespace of the BackendGroup
+	// +optional
+	LBNamespace string `json:"lbNamespace,omitempty"`
+	// +optional
+	Service *ServiceBackend `json:"service,omitempty"`
+	// +optional
+	Pods *PodBackend `json:"pods,omitempty"`
+	// +optional
+	Static []string `json:"static,omitempty"`
+	// +optional
+	Nodes *NodeBackend `json:"nodes,omitempty"`
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// +optional
+	EnsurePolicy *EnsurePolicyConfig `json:"ensurePolicy,omitempty"`
+}
+
+type ServiceBackend struct {
+	Name  string         `json:"name"`
+	Ports []PortSelector `json:"ports"`
+	// NodeSelector selects nodes whose NodePort are registered, all nodes are selected if NodeSelector is nil
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// Mode defaults to NodePort
+	// +optional
+	Mode ServiceBackendMode `json:"mode,omitempty"`
+}
+
+// ServiceBackendMode determines which backends are registered for a service
+type ServiceBackendMode string
+
+const (
+	// ServiceModeNodePort registers NodePort of all nodes selected by nodeSelector
+	ServiceModeNodePort ServiceBackendMode = "NodePort"
+	// ServiceModeEndpointPod registers ready endpoints of the service, i.e., the pods and their target port
+	ServiceModeEndpointPod ServiceBackendMode = "EndpointPod"
+	// ServiceModeEndpointNode registers NodePort of nodes selected by nodeSelector that host ready endpoints
+	ServiceModeEndpointNode ServiceBackendMode = "EndpointNode"
+)
+
+// NodeBackend registers addresses of nodes with a fixed port
+type NodeBackend struct {
+	Port PortSelector `json:"port"`
+	// Selector selects nodes by label, all nodes are selected if Selector is nil
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+type PodBackend struct {
+	Ports []PortSelector `json:"ports"`
+	// Selector selects pods by label, only one of Selector and ByName can be specified
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Except are names of pods excluded from pods selected by Selector
+	// +optional
+	Except []string `json:"except,omitempty"`
+	// +optional
+	ByName []string `json:"byName,omitempty"`
+	// +optional
+	Drain *DrainConfig `json:"drain,omitempty"`
+	// ReadinessGate enables condition lbcf.tke.cloud.tencent.com/registered on selected pods
+	// +optional
+	ReadinessGate bool `json:"readinessGate,omitempty"`
+	// +optional
+	Weight *WeightConfig `json:"weight,omitempty"`
+}
+
+// WeightConfig determines weight of pod backends.
+// Weight is read from pod annotation, if the annotation is not set or invalid, DefaultWeight is used.
+type WeightConfig struct {
+	// Annotation defaults to lbcf.tke.cloud.tencent.com/weight
+	// +optional
+	Annotation string `json:"annotation,omitempty"`
+	// +optional
+	DefaultWeight *int32 `json:"defaultWeight,omitempty"`
+}
+
+// DrainConfig enables drain mode of pod backends.
+// The finalizer added in drain mode does not delay termination of containers, a preStop hook is required.
+type DrainConfig struct {
+	DrainPeriod metav1.Duration `json:"drainPeriod"`
+}
+
+// PortSelector selects a port by number or by name, only one of PortNumber and PortName can be specified.
+type PortSelector struct {
+	// +optional
+	PortNumber int32 `json:"portNumber,omitempty"`
+	// +optional
+	PortName string `json:"portName,omitempty"`
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+}
+
+type BackendGroupStatus struct {
+	Backends           int32 `json:"backends"`
+	RegisteredBackends int32 `json:"registeredBackends"`
+	// +optional
+	Ports []BackendGroupPortStatus `json:"ports,omitempty"`
+}
+
+// BackendGroupPortStatus is the number of backends of a port in BackendGroup
+type BackendGroupPortStatus struct {
+	Port               PortSelector `json:"port"`
+	Backends           int32        `json:"backends"`
+	RegisteredBackends int32        `json:"registeredBackends"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackendGroupList is a top-level list type.
+type BackendGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []BackendGroup `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LoadBalancerDriver is a top-level type.
+type LoadBalancerDriver struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LoadBalancerDriverSpec   `json:"spec"`
+	Status LoadBalancerDriverStatus `json:"status,omitempty"`
+}
+
+type DriverType string
+
+const (
+	WebhookDriver DriverType = "Webhook"
+	GRPCDriver    DriverType = "GRPC"
+)
+
+// ProtocolVersion is the version of the webhook protocol a driver implements
+type ProtocolVersion string
+
+const (
+	// ProtocolVersionV1 requires the driver to implement all webhooks except the optional ones
+	ProtocolVersionV1 ProtocolVersion = "v1"
+	// ProtocolVersionV2 requires the driver to implement only the core webhooks
+	ProtocolVersionV2 ProtocolVersion = "v2"
+)
+
+type LoadBalancerDriverSpec struct {
+	DriverType DriverType `json:"driverType"`
+	URL        string     `json:"url"`
+	// ProtocolVersion defaults to v1
+	// +optional
+	ProtocolVersion ProtocolVersion `json:"protocolVersion,omitempty"`
+	// +optional
+	Webhooks []WebhookConfig `json:"webhooks,omitempty"`
+	// +optional
+	ClientConfig *DriverClientConfig `json:"clientConfig,omitempty"`
+	// +optional
+	HealthCheck *DriverHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// DriverHealthCheck configures how lbcf-controller probes a driver.
+type DriverHealthCheck struct {
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Period defaults to 30s
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+	// Timeout defaults to 5s
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// DriverClientConfig references Secrets in the namespace of the LoadBalancerDriver,
+// which are used to authenticate the driver and lbcf-controller to each other.
+type DriverClientConfig struct {
+	// CASecret is a Secret containing the CA bundle(ca.crt) used to verify the driver's serving certificate.
+	// +optional
+	CASecret *corev1.LocalObjectReference `json:"caSecret,omitempty"`
+	// ClientCertSecret is a Secret of type kubernetes.io/tls containing the client certificate(tls.crt)
+	// and key(tls.key) presented to the driver.
+	// +optional
+	ClientCertSecret *corev1.LocalObjectReference `json:"clientCertSecret,omitempty"`
+	// TokenSecret is a Secret containing a bearer token(token) sent to the driver in the Authorization header.
+	// +optional
+	TokenSecret *corev1.LocalObjectReference `json:"tokenSecret,omitempty"`
+}
+
+type WebhookConfig struct {
+	Name string `json:"name"`
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+type LoadBalancerDriverStatus struct {
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+	// LastProbeLatency is the latency of the last health check
+	// +optional
+	LastProbeLatency *metav1.Duration `json:"lastProbeLatency,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LoadBalancerDriverList is a top-level list type.
+type LoadBalancerDriverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []LoadBalancerDriver `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackendRecord is a top-level type.
+type BackendRecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackendRecordSpec   `json:"spec"`
+	Status BackendRecordStatus `json:"status,omitempty"`
+}
+
+type BackendRecordSpec struct {
+	LBName       string            `json:"lbName"`
+	LBDriver     string            `json:"lbDriver"`
+	LBInfo       map[string]string `json:"lbInfo"`
+	LBAttributes map[string]string `json:"lbAttributes"`
+	Parameters   map[string]string `json:"parameters"`
+	// +optional
+	LBNamespace string `json:"lbNamespace,omitempty"`
+	// +optional
+	PodBackendInfo *PodBackendRecord `json:"podBackend,omitempty"`
+	// +optional
+	ServiceBackendInfo *ServiceBackendRecord `json:"serviceBackend,omitempty"`
+	// +optional
+	StaticAddr *string `json:"staticAddr,omitempty"`
+	// +optional
+	NodeBackendInfo *NodeBackendRecord `json:"nodeBackend,omitempty"`
+	// +optional
+	EnsurePolicy *EnsurePolicyConfig `json:"ensurePolicy,omitempty"`
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+type PodBackendRecord struct {
+	Name string       `json:"name"`
+	Port PortSelector `json:"port"`
+	// +optional
+	DrainPeriod *metav1.Duration `json:"drainPeriod,omitempty"`
+	// +optional
+	ReadinessGate bool `json:"readinessGate,omitempty"`
+}
+
+type ServiceBackendRecord struct {
+	Name     string       `json:"name"`
+	Port     PortSelector `json:"port"`
+	NodePort int32        `json:"nodePort"`
+	NodeName string       `json:"nodeName"`
+}
+
+type NodeBackendRecord struct {
+	Name string       `json:"name"`
+	Port PortSelector `json:"port"`
+}
+
+type BackendRecordStatus struct {
+	// +optional
+	BackendAddr string `json:"backendAddr,omitempty"`
+	// +optional
+	InjectedInfo map[string]string `json:"injectedInfo,omitempty"`
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BackendRecordList is a top-level list type.
+type BackendRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []BackendRecord `json:"items"`
+}
+
+// Condition is the condition used by all resources
+type Condition struct {
+	// Type is the type of the condition.
+	Type ConditionType `json:"type"`
+	// Status is the status of the condition.
+	// Can be True, False, Unknown.
+	Status ConditionStatus `json:"status"`
+	// Last time the driver is probed, only used by condition Healthy of LoadBalancerDriver.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason ConditionReason `json:"reason,omitempty"`
+	// Human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type ConditionType string
+
+const (
+	// conditions of LoadBalancer
+	LBCreated          ConditionType = "Created"
+	LBAttributesSynced ConditionType = "AttributesSynced"
+	LBImported         ConditionType = "Imported"
+
+	// conditions of LoadBalancerDriver
+	DriverAccepted    ConditionType = "Accepted"
+	DriverHealthy     ConditionType = "Healthy"
+	DriverCircuitOpen ConditionType = "CircuitOpen"
+
+	// conditions of BackendRecord
+	BackendRegistered ConditionType = "Registered"
+	BackendDrained    ConditionType = "Drained"
+)
+
+type ConditionStatus string
+
+const (
+	ConditionTrue    ConditionStatus = "True"
+	ConditionFalse   ConditionStatus = "False"
+	ConditionUnknown ConditionStatus = "Unknown"
+)
+
+type ConditionReason string
+
+const (
+	ReasonOperationInProgress ConditionReason = "OperationInProgres"
+	ReasonOperationFailed     ConditionReason = "OperationFailed"
+	ReasonInvalidResponse     ConditionReason = "InvalidResponse"
+	ReasonDraining            ConditionReason = "Draining"
+)
+
+type EnsurePolicyType string
+
+const (
+	PolicyIfNotSucc EnsurePolicyType = "IfNotSucc"
+	PolicyAlways    EnsurePolicyType = "Always"
+)
+
+type EnsurePolicyConfig struct {
+	Policy EnsurePolicyType `json:"policy"`
+	// +optional
+	MinPeriod *metav1.Duration `json:"minPeriod,omitempty"`
+}
@@ -39,11 +39,29 @@ const (
 	LabelGroupName      = "lbcf.tke.cloud.tencent.com/backend-group"
 	LabelServiceName    = "lbcf.tke.cloud.tencent.com/backend-service"
 	LabelPodName        = "lbcf.tke.cloud.tencent.com/backend-pod"
+	LabelNodeName       = "lbcf.tke.cloud.tencent.com/backend-node"
 	LabelStaticAddr     = "lbcf.tke.cloud.tencent.com/backend-static-addr"
 
 	FinalizerDeleteLB               = "lbcf.tke.cloud.tencent.com/delete-load-loadbalancer"
 	FinalizerDeregisterBackend      = "lbcf.tke.cloud.tencent.com/deregister-backend"
 	FinalizerDeregisterBackendGroup = "lbcf.tke.cloud.tencent.com/deregister-backend-group"
+	// PodConditionRegistered is the pod condition set by LBCF for readiness gate,
+	// it is True when all BackendRecords of the pod with readinessGate enabled are registered
+	PodConditionRegistered = "lbcf.tke.cloud.tencent.com/registered"
+
+	// AnnotationWeight is the default pod annotation from which weight of pod backends is read
+	AnnotationWeight = "lbcf.tke.cloud.tencent.com/weight"
+
+	// FinalizerDrainPod is added to pods selected by BackendGroups in drain mode,
+	// it is removed after all backends of the pod are deregistered and drained.
+	// It only keeps the Pod object, containers are terminated as usual
+	FinalizerDrainPod = "lbcf.tke.cloud.tencent.com/drain-backend"
+
+	// AnnotationForceDeletionPolicy must be set to "true" to change deletionPolicy of a created LoadBalancer
+	AnnotationForceDeletionPolicy = "lbcf.tke.cloud.tencent.com/force-deletion-policy"
+
+	// AllNamespaces in LoadBalancer.spec.allowedNamespaces allows BackendGroups in all namespaces
+	AllNamespaces = "*"
 )
 
 // +genclient
@@ -65,8 +83,28 @@ type LoadBalancerSpec struct {
 	Attributes map[string]string `json:"attributes,omitempty"`
 	// +optional
 	EnsurePolicy *EnsurePolicyConfig `json:"ensurePolicy,omitempty"`
+	// AllowedNamespaces are namespaces whose BackendGroups are allowed to use this LoadBalancer,
+	// "*" allows all namespaces. BackendGroups in the same namespace are always allowed.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// Import adopts an existing load balancer by calling webhook importLoadBalancer instead of createLoadBalancer
+	// +optional
+	Import bool `json:"import,omitempty"`
+	// DeletionPolicy defaults to Retain for imported load balancers, and Delete for others
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
+// DeletionPolicy determines what happens to the load balancer when a LoadBalancer is deleted
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete calls webhook deleteLoadBalancer before the LoadBalancer is deleted
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain deletes the LoadBalancer without calling webhook deleteLoadBalancer
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // LoadBalancerList is a top-level list type. The client methods for lists are automatically created.
@@ -105,6 +143,7 @@ type LoadBalancerConditionType string
 const (
 	LBCreated          LoadBalancerConditionType = "Created"
 	LBAttributesSynced LoadBalancerConditionType = "AttributesSynced"
+	LBImported         LoadBalancerConditionType = "Imported"
 )
 
 // +genclient
@@ -121,6 +160,9 @@ type BackendGroup struct {
 
 type BackendGroupSpec struct {
 	LBName string `json:"lbName"`
+	// LBNamespace is the namespace of the LoadBalancer, defaults to the namespace of the BackendGroup
+	// +optional
+	LBNamespace string `json:"lbNamespace,omitempty"`
 	// +optional
 	Service *ServiceBackend `json:"service,omitempty"`
 	// +optional
@@ -128,6 +170,8 @@ type BackendGroupSpec struct {
 	// +optional
 	Static []string `json:"static,omitempty"`
 	// +optional
+	Nodes *NodeBackend `json:"nodes,omitempty"`
+	// +optional
 	Parameters map[string]string `json:"parameters,omitempty"`
 	// +optional
 	EnsurePolicy *EnsurePolicyConfig `json:"ensurePolicy,omitempty"`
@@ -136,26 +180,98 @@ type BackendGroupSpec struct {
 type ServiceBackend struct {
 	Name string       `json:"name"`
 	Port PortSelector `json:"port,omitempty"`
+	// Ports is used instead of Port to register multiple ports of the service
+	// +optional
+	Ports []PortSelector `json:"ports,omitempty"`
 	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// NodeMatchExpressions are ANDed with NodeSelector
+	// +optional
+	NodeMatchExpressions []metav1.LabelSelectorRequirement `json:"nodeMatchExpressions,omitempty"`
+	// Mode defaults to NodePort
+	// +optional
+	Mode ServiceBackendMode `json:"mode,omitempty"`
 }
 
-type PodBackend struct {
+// ServiceBackendMode determines which backends are registered for a service
+type ServiceBackendMode string
+
+const (
+	// ServiceModeNodePort registers NodePort of all nodes selected by nodeSelector
+	ServiceModeNodePort ServiceBackendMode = "NodePort"
+	// ServiceModeEndpointPod registers ready endpoints of the service, i.e., the pods and their target port
+	ServiceModeEndpointPod ServiceBackendMode = "EndpointPod"
+	// ServiceModeEndpointNode registers NodePort of nodes selected by nodeSelector that host ready endpoints,
+	// which matches the semantics of externalTrafficPolicy: Local
+	ServiceModeEndpointNode ServiceBackendMode = "EndpointNode"
+)
+
+// NodeBackend registers addresses of nodes with a fixed port,
+// it is usually used for daemons running in host network, e.g., ingress controllers
+type NodeBackend struct {
 	Port PortSelector `json:"port"`
+	// Selector selects nodes by label, all nodes are selected if Selector is empty
+	// +optional
+	Selector map[string]string `json:"selector,omitempty"`
+	// MatchExpressions are ANDed with Selector
+	// +optional
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+}
+
+type PodBackend struct {
+	Port PortSelector `json:"port,omitempty"`
+	// Ports is used instead of Port to register multiple ports of the pods
+	// +optional
+	Ports []PortSelector `json:"ports,omitempty"`
 	// +optional
 	ByLabel *SelectPodByLabel `json:"byLabel,omitempty"`
 	// +optional
 	ByName []string `json:"byName,omitempty"`
+	// +optional
+	Drain *DrainConfig `json:"drain,omitempty"`
+	// ReadinessGate enables condition lbcf.tke.cloud.tencent.com/registered on selected pods
+	// +optional
+	ReadinessGate bool `json:"readinessGate,omitempty"`
+	// +optional
+	Weight *WeightConfig `json:"weight,omitempty"`
+}
+
+// WeightConfig determines weight of pod backends.
+// Weight is read from pod annotation, if the annotation is not set or invalid, DefaultWeight is used.
+type WeightConfig struct {
+	// Annotation defaults to lbcf.tke.cloud.tencent.com/weight
+	// +optional
+	Annotation string `json:"annotation,omitempty"`
+	// +optional
+	DefaultWeight *int32 `json:"defaultWeight,omitempty"`
+}
+
+// DrainConfig enables drain mode of pod backends.
+// In drain mode, Pod objects are kept by finalizer until the backends are deregistered
+// and drainPeriod has passed after deregistration.
+// The finalizer does not delay termination of containers, a preStop hook is required to keep them serving.
+type DrainConfig struct {
+	DrainPeriod Duration `json:"drainPeriod"`
 }
 
+// PortSelector selects a port by number or by name, only one of PortNumber and PortName can be specified.
+// PortName is resolved against container ports for pod backends, and against ServicePort.Name for service backends.
+// PortNumber is always set to the resolved number in BackendRecords.
 type PortSelector struct {
-	PortNumber int32 `json:"portNumber"`
+	// +optional
+	PortNumber int32 `json:"portNumber,omitempty"`
+	// +optional
+	PortName string `json:"portName,omitempty"`
 	// +optional
 	Protocol string `json:"protocol,omitempty"`
 }
 
 type SelectPodByLabel struct {
-	Selector map[string]string `json:"selector"`
+	// +optional
+	Selector map[string]string `json:"selector,omitempty"`
+	// MatchExpressions are ANDed with Selector, at least one of them must be specified
+	// +optional
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
 	// +optional
 	Except []string `json:"except,omitempty"`
 }
@@ -163,6 +279,16 @@ type SelectPodByLabel struct {
 type BackendGroupStatus struct {
 	Backends           int32 `json:"backends"`
 	RegisteredBackends int32 `json:"registeredBackends"`
+	// Ports is only reported when spec.pods.ports or spec.service.ports is used
+	// +optional
+	Ports []BackendGroupPortStatus `json:"ports,omitempty"`
+}
+
+// BackendGroupPortStatus is the number of backends of a port in BackendGroup
+type BackendGroupPortStatus struct {
+	Port               PortSelector `json:"port"`
+	Backends           int32        `json:"backends"`
+	RegisteredBackends int32        `json:"registeredBackends"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -192,15 +318,72 @@ type DriverType string
 
 const (
 	WebhookDriver DriverType = "Webhook"
+	GRPCDriver    DriverType = "GRPC"
+)
+
+// ProtocolVersion is the version of the webhook protocol a driver implements
+type ProtocolVersion string
+
+const (
+	// ProtocolVersionV1 requires the driver to implement all webhooks except the optional ones
+	ProtocolVersionV1 ProtocolVersion = "v1"
+	// ProtocolVersionV2 requires the driver to implement only the core webhooks,
+	// other webhooks are called only if they are declared in spec.webhooks
+	ProtocolVersionV2 ProtocolVersion = "v2"
 )
 
 type LoadBalancerDriverSpec struct {
 	DriverType string `json:"driverType"`
 	Url        string `json:"url"`
+	// ProtocolVersion defaults to v1
+	// +optional
+	ProtocolVersion ProtocolVersion `json:"protocolVersion,omitempty"`
 	// +optional
 	Webhooks []WebhookConfig `json:"webhooks,omitempty"`
+	// +optional
+	ClientConfig *DriverClientConfig `json:"clientConfig,omitempty"`
+	// HealthCheck enables periodic probing of the driver
+	// +optional
+	HealthCheck *DriverHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// DriverHealthCheck configures how lbcf-controller probes a driver.
+//
+// Drivers of type Webhook are probed by HTTP GET on Path of spec.url, any 2xx status code means healthy.
+// Drivers of type GRPC are probed with the standard gRPC health checking protocol, Path is ignored.
+type DriverHealthCheck struct {
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Period defaults to 30s
+	// +optional
+	Period *Duration `json:"period,omitempty"`
+	// Timeout defaults to 5s
+	// +optional
+	Timeout *Duration `json:"timeout,omitempty"`
 }
 
+// DriverClientConfig references Secrets in the namespace of the LoadBalancerDriver,
+// which are used to authenticate the driver and lbcf-controller to each other.
+type DriverClientConfig struct {
+	// CASecret is a Secret containing the CA bundle(ca.crt) used to verify the driver's serving certificate.
+	// +optional
+	CASecret string `json:"caSecret,omitempty"`
+	// ClientCertSecret is a Secret of type kubernetes.io/tls containing the client certificate(tls.crt)
+	// and key(tls.key) presented to the driver.
+	// +optional
+	ClientCertSecret string `json:"clientCertSecret,omitempty"`
+	// TokenSecret is a Secret containing a bearer token(token) sent to the driver in the Authorization header.
+	// +optional
+	TokenSecret string `json:"tokenSecret,omitempty"`
+}
+
+const (
+	// SecretKeyCA is the key of CA bundle in the Secret referenced by DriverClientConfig.CASecret
+	SecretKeyCA = "ca.crt"
+	// SecretKeyToken is the key of bearer token in the Secret referenced by DriverClientConfig.TokenSecret
+	SecretKeyToken = "token"
+)
+
 type WebhookConfig struct {
 	Name string `json:"name"`
 	// +optional
@@ -211,6 +394,10 @@ type LoadBalancerDriverConditionType string
 
 const (
 	DriverAccepted LoadBalancerDriverConditionType = "Accepted"
+	// DriverHealthy is maintained only if spec.healthCheck is configured
+	DriverHealthy LoadBalancerDriverConditionType = "Healthy"
+	// DriverCircuitOpen is True if calls to the driver fail fast because too many recent calls failed
+	DriverCircuitOpen LoadBalancerDriverConditionType = "CircuitOpen"
 )
 
 type LoadBalancerDriverCondition struct {
@@ -219,6 +406,9 @@ type LoadBalancerDriverCondition struct {
 	// Status is the status of the condition.
 	// Can be True, False, Unknown.
 	Status ConditionStatus `json:"status"`
+	// Last time the driver is probed, only used by condition Healthy.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
 	// Last time the condition transitioned from one status to another.
 	// +optional
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
@@ -232,6 +422,9 @@ type LoadBalancerDriverCondition struct {
 
 type LoadBalancerDriverStatus struct {
 	Conditions []LoadBalancerDriverCondition `json:"conditions"`
+	// LastProbeLatency is the latency of the last health check
+	// +optional
+	LastProbeLatency *Duration `json:"lastProbeLatency,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -263,6 +456,9 @@ type BackendRecordSpec struct {
 	LBInfo       map[string]string `json:"lbInfo"`
 	LBAttributes map[string]string `json:"lbAttributes"`
 	Parameters   map[string]string `json:"parameters"`
+	// LBNamespace is the namespace of the LoadBalancer, defaults to the namespace of the BackendRecord
+	// +optional
+	LBNamespace string `json:"lbNamespace,omitempty"`
 	// +optional
 	PodBackendInfo *PodBackendRecord `json:"podBackend,omitempty"`
 	// +optional
@@ -270,12 +466,20 @@ type BackendRecordSpec struct {
 	// +optional
 	StaticAddr *string `json:"staticAddr,omitempty"`
 	// +optional
+	NodeBackendInfo *NodeBackendRecord `json:"nodeBackend,omitempty"`
+	// +optional
 	EnsurePolicy *EnsurePolicyConfig `json:"ensurePolicy,omitempty"`
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
 }
 
 type PodBackendRecord struct {
 	Name string       `json:"name"`
 	Port PortSelector `json:"port"`
+	// +optional
+	DrainPeriod *Duration `json:"drainPeriod,omitempty"`
+	// +optional
+	ReadinessGate bool `json:"readinessGate,omitempty"`
 }
 
 type ServiceBackendRecord struct {
@@ -286,7 +490,8 @@ type ServiceBackendRecord struct {
 }
 
 type NodeBackendRecord struct {
-	Name string `json:"name"`
+	Name string       `json:"name"`
+	Port PortSelector `json:"port"`
 }
 
 type ServicePort struct {
@@ -394,6 +599,8 @@ type BackendRecordConditionType string
 
 const (
 	BackendRegistered BackendRecordConditionType = "Registered"
+	// BackendDrained is False while the deregistered backend is draining, and True after drainPeriod has passed
+	BackendDrained BackendRecordConditionType = "Drained"
 )
 
 type BackendRecordCondition struct {
@@ -461,6 +668,7 @@ const (
 	ReasonOperationInProgress ConditionReason = "OperationInProgres"
 	ReasonOperationFailed     ConditionReason = "OperationFailed"
 	ReasonInvalidResponse     ConditionReason = "InvalidResponse"
+	ReasonDraining            ConditionReason = "Draining"
 )
 
 func (c ConditionReason) String() string {
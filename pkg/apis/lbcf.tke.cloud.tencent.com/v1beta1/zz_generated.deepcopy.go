@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -30,7 +31,7 @@ func (in *BackendGroup) DeepCopyInto(out *BackendGroup) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -103,6 +104,11 @@ func (in *BackendGroupSpec) DeepCopyInto(out *BackendGroupSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(NodeBackend)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
 		*out = make(map[string]string, len(*in))
@@ -128,9 +134,31 @@ func (in *BackendGroupSpec) DeepCopy() *BackendGroupSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendGroupPortStatus) DeepCopyInto(out *BackendGroupPortStatus) {
+	*out = *in
+	out.Port = in.Port
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendGroupPortStatus.
+func (in *BackendGroupPortStatus) DeepCopy() *BackendGroupPortStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendGroupPortStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendGroupStatus) DeepCopyInto(out *BackendGroupStatus) {
 	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]BackendGroupPortStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -249,7 +277,7 @@ func (in *BackendRecordSpec) DeepCopyInto(out *BackendRecordSpec) {
 	if in.PodBackendInfo != nil {
 		in, out := &in.PodBackendInfo, &out.PodBackendInfo
 		*out = new(PodBackendRecord)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ServiceBackendInfo != nil {
 		in, out := &in.ServiceBackendInfo, &out.ServiceBackendInfo
@@ -261,11 +289,21 @@ func (in *BackendRecordSpec) DeepCopyInto(out *BackendRecordSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.NodeBackendInfo != nil {
+		in, out := &in.NodeBackendInfo, &out.NodeBackendInfo
+		*out = new(NodeBackendRecord)
+		**out = **in
+	}
 	if in.EnsurePolicy != nil {
 		in, out := &in.EnsurePolicy, &out.EnsurePolicy
 		*out = new(EnsurePolicyConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -309,6 +347,65 @@ func (in *BackendRecordStatus) DeepCopy() *BackendRecordStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverClientConfig) DeepCopyInto(out *DriverClientConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverClientConfig.
+func (in *DriverClientConfig) DeepCopy() *DriverClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainConfig) DeepCopyInto(out *DrainConfig) {
+	*out = *in
+	out.DrainPeriod = in.DrainPeriod
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainConfig.
+func (in *DrainConfig) DeepCopy() *DrainConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriverHealthCheck) DeepCopyInto(out *DriverHealthCheck) {
+	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriverHealthCheck.
+func (in *DriverHealthCheck) DeepCopy() *DriverHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DriverHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Duration) DeepCopyInto(out *Duration) {
 	*out = *in
@@ -438,6 +535,7 @@ func (in *LoadBalancerDriver) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerDriverCondition) DeepCopyInto(out *LoadBalancerDriverCondition) {
 	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
@@ -493,6 +591,16 @@ func (in *LoadBalancerDriverSpec) DeepCopyInto(out *LoadBalancerDriverSpec) {
 		*out = make([]WebhookConfig, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClientConfig != nil {
+		in, out := &in.ClientConfig, &out.ClientConfig
+		*out = new(DriverClientConfig)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(DriverHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -516,6 +624,11 @@ func (in *LoadBalancerDriverStatus) DeepCopyInto(out *LoadBalancerDriverStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastProbeLatency != nil {
+		in, out := &in.LastProbeLatency, &out.LastProbeLatency
+		*out = new(Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -584,6 +697,11 @@ func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 		*out = new(EnsurePolicyConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -627,9 +745,41 @@ func (in *LoadBalancerStatus) DeepCopy() *LoadBalancerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeBackend) DeepCopyInto(out *NodeBackend) {
+	*out = *in
+	out.Port = in.Port
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeBackend.
+func (in *NodeBackend) DeepCopy() *NodeBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeBackendRecord) DeepCopyInto(out *NodeBackendRecord) {
 	*out = *in
+	out.Port = in.Port
 	return
 }
 
@@ -647,6 +797,11 @@ func (in *NodeBackendRecord) DeepCopy() *NodeBackendRecord {
 func (in *PodBackend) DeepCopyInto(out *PodBackend) {
 	*out = *in
 	out.Port = in.Port
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]PortSelector, len(*in))
+		copy(*out, *in)
+	}
 	if in.ByLabel != nil {
 		in, out := &in.ByLabel, &out.ByLabel
 		*out = new(SelectPodByLabel)
@@ -657,6 +812,16 @@ func (in *PodBackend) DeepCopyInto(out *PodBackend) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DrainConfig)
+		**out = **in
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(WeightConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -674,6 +839,11 @@ func (in *PodBackend) DeepCopy() *PodBackend {
 func (in *PodBackendRecord) DeepCopyInto(out *PodBackendRecord) {
 	*out = *in
 	out.Port = in.Port
+	if in.DrainPeriod != nil {
+		in, out := &in.DrainPeriod, &out.DrainPeriod
+		*out = new(Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -713,6 +883,13 @@ func (in *SelectPodByLabel) DeepCopyInto(out *SelectPodByLabel) {
 			(*out)[key] = val
 		}
 	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Except != nil {
 		in, out := &in.Except, &out.Except
 		*out = make([]string, len(*in))
@@ -735,6 +912,11 @@ func (in *SelectPodByLabel) DeepCopy() *SelectPodByLabel {
 func (in *ServiceBackend) DeepCopyInto(out *ServiceBackend) {
 	*out = *in
 	out.Port = in.Port
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]PortSelector, len(*in))
+		copy(*out, *in)
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
@@ -742,6 +924,13 @@ func (in *ServiceBackend) DeepCopyInto(out *ServiceBackend) {
 			(*out)[key] = val
 		}
 	}
+	if in.NodeMatchExpressions != nil {
+		in, out := &in.NodeMatchExpressions, &out.NodeMatchExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -805,3 +994,24 @@ func (in *WebhookConfig) DeepCopy() *WebhookConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeightConfig) DeepCopyInto(out *WeightConfig) {
+	*out = *in
+	if in.DefaultWeight != nil {
+		in, out := &in.DefaultWeight, &out.DefaultWeight
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeightConfig.
+func (in *WeightConfig) DeepCopy() *WeightConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WeightConfig)
+	in.DeepCopyInto(out)
+	return out
+}
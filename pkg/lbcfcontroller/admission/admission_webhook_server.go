@@ -18,8 +18,14 @@
 package admission
 
 import (
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"sync/atomic"
+	"time"
 
 	"tkestack.io/lb-controlling-framework/cmd/lbcf-controller/app/context"
 	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
@@ -27,19 +33,32 @@ import (
 	"github.com/emicklei/go-restful"
 	"k8s.io/api/admission/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog"
 )
 
 // NewWebhookServer creates a new Server
-func NewWebhookServer(context *context.Context, crtFile string, keyFile string) *Server {
+func NewWebhookServer(context *context.Context, crtFile string, keyFile string, addr string) *Server {
 	s := &Server{
 		context: context,
 		admitWebhook: NewAdmitter(context.LBInformer.Lister(),
 			context.LBDriverInformer.Lister(),
 			context.BRInformer.Lister(),
-			util.NewWebhookInvoker()),
-		crtFile: crtFile,
-		keyFile: keyFile,
+			util.NewWebhookInvoker(context.SecretGetter)),
+		addr: addr,
+	}
+	if context.Cfg.SelfSignedCert {
+		s.certs = newSelfSignedCertProvider(context.K8sClient, context.Cfg)
+	} else {
+		s.certs = newFileCertProvider(crtFile, keyFile, context.Cfg.CABundleFile)
+	}
+	if context.Cfg.RegisterWebhooks {
+		registrar, err := newWebhookRegistrar(context.K8sClient, context.Cfg, s.admitRoutes())
+		if err != nil {
+			klog.Fatal(err)
+		}
+		s.registrar = registrar
 	}
 	return s
 }
@@ -48,8 +67,10 @@ func NewWebhookServer(context *context.Context, crtFile string, keyFile string)
 type Server struct {
 	context      *context.Context
 	admitWebhook Webhook
-	crtFile      string
-	keyFile      string
+	addr         string
+	certs        certProvider
+	registrar    *webhookRegistrar
+	listening    int32
 }
 
 // Start starts the server in a new goroutine
@@ -57,28 +78,70 @@ func (s *Server) Start() {
 	ws := new(restful.WebService)
 	ws.Path("/")
 
-	ws.Route(ws.POST("mutate-load-balancer").To(s.MutateAdmitLoadBalancer).
-		Consumes(restful.MIME_JSON))
-	ws.Route(ws.POST("mutate-load-balancer-driver").To(s.MutateAdmitLoadBalancerDriver).
-		Consumes(restful.MIME_JSON))
-	ws.Route(ws.POST("mutate-backend-broup").To(s.MutateAdmitBackendGroup).
-		Consumes(restful.MIME_JSON))
+	for _, route := range s.admitRoutes() {
+		ws.Route(ws.POST(route.path).To(route.handler).
+			Consumes(restful.MIME_JSON))
+	}
 
-	ws.Route(ws.POST("validate-load-balancer").To(s.ValidateAdmitLoadBalancer).
-		Consumes(restful.MIME_JSON))
-	ws.Route(ws.POST("validate-load-balancer-driver").To(s.ValidateAdmitLoadBalancerDriver).
-		Consumes(restful.MIME_JSON))
-	ws.Route(ws.POST("validate-backend-group").To(s.ValidateAdmitBackendGroup).
+	ws.Route(ws.POST("convert").To(s.Convert).
 		Consumes(restful.MIME_JSON))
 
 	restful.Add(ws)
 
 	go func() {
 		s.context.WaitForCacheSync()
-		klog.Fatal(http.ListenAndServeTLS(":443", s.crtFile, s.keyFile, nil))
+		wait.PollImmediateInfinite(5*time.Second, func() (bool, error) {
+			if err := s.certs.sync(); err != nil {
+				klog.Errorf("load serving certificate failed: %v", err)
+				return false, nil
+			}
+			return true, nil
+		})
+		go wait.Until(s.syncCert, certCheckPeriod, wait.NeverStop)
+		if s.registrar != nil {
+			go s.registerWebhooks()
+		}
+
+		listener, err := net.Listen("tcp", s.addr)
+		if err != nil {
+			klog.Fatal(err)
+		}
+		atomic.StoreInt32(&s.listening, 1)
+		server := &http.Server{
+			TLSConfig: &tls.Config{GetCertificate: s.certs.GetCertificate},
+		}
+		err = server.ServeTLS(listener, "", "")
+		atomic.StoreInt32(&s.listening, 0)
+		klog.Fatal(err)
 	}()
 }
 
+// CheckListening returns an error if the server is not listening or has no serving certificate
+func (s *Server) CheckListening() error {
+	if atomic.LoadInt32(&s.listening) == 0 {
+		return fmt.Errorf("admission webhook server is not listening on %s", s.addr)
+	}
+	_, err := s.certs.GetCertificate(nil)
+	return err
+}
+
+// registerWebhooks retries until webhooks are registered
+func (s *Server) registerWebhooks() {
+	wait.PollImmediateInfinite(5*time.Second, func() (bool, error) {
+		if err := s.registrar.register(s.certs.caBundle()); err != nil {
+			klog.Errorf("register webhooks failed: %v", err)
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
+func (s *Server) syncCert() {
+	if err := s.certs.sync(); err != nil {
+		klog.Errorf("reload serving certificate failed: %v", err)
+	}
+}
+
 // ValidateAdmitLoadBalancer implements ValidatingWebHook for LoadBalancer
 func (s *Server) ValidateAdmitLoadBalancer(req *restful.Request, rsp *restful.Response) {
 	serveValidate(req, rsp,
@@ -118,16 +181,82 @@ func (s *Server) MutateAdmitBackendGroup(req *restful.Request, rsp *restful.Resp
 	serveMutate(req, rsp, s.admitWebhook.MutateBackendGroup)
 }
 
+const (
+	admissionV1      = "admission.k8s.io/v1"
+	admissionV1beta1 = "admission.k8s.io/v1beta1"
+)
+
+// parseAdmissionReview decodes both admission.k8s.io/v1 and admission.k8s.io/v1beta1 AdmissionReview.
+// The two versions share the same schema, so v1 is decoded into v1beta1.AdmissionReview as well,
+// and the apiVersion is kept in TypeMeta to answer in the version received.
+//
+// If the AdmissionReview can not be handled, a failure is answered with the request.uid received,
+// otherwise kube-apiserver can not match the response with its request.
 func parseAdmissionReview(req *restful.Request, rsp *restful.Response) *v1beta1.AdmissionReview {
+	body, err := ioutil.ReadAll(req.Request.Body)
+	if err != nil {
+		responseAndLog(failedAdmissionReview(nil, fmt.Errorf("read AdmissionReview failed: %v", err)), rsp)
+		return nil
+	}
 	ar := &v1beta1.AdmissionReview{}
-	if err := req.ReadEntity(ar); err != nil {
-		ar.Response = toAdmissionResponse(fmt.Errorf("decode AdmissionReview failed: %v", err))
-		responseAndLog(ar, rsp)
+	if err := json.Unmarshal(body, ar); err != nil {
+		// decode only the fields needed to answer, ignoring those in wrong format, e.g., request.object
+		received := &admissionReviewHeader{}
+		json.Unmarshal(body, received)
+		responseAndLog(failedAdmissionReview(received.review(),
+			fmt.Errorf("decode AdmissionReview failed: %v", err)), rsp)
+		return nil
+	}
+	if ar.APIVersion != admissionV1 && ar.APIVersion != admissionV1beta1 {
+		responseAndLog(failedAdmissionReview(ar,
+			fmt.Errorf("unsupported AdmissionReview version %q", ar.APIVersion)), rsp)
+		return nil
+	}
+	if ar.Request == nil {
+		responseAndLog(failedAdmissionReview(ar, fmt.Errorf("empty AdmissionReview request")), rsp)
 		return nil
 	}
 	return ar
 }
 
+// admissionReviewHeader is the part of AdmissionReview that is used to answer a request failed to decode
+type admissionReviewHeader struct {
+	v1.TypeMeta `json:",inline"`
+	Request     *struct {
+		UID types.UID `json:"uid"`
+	} `json:"request,omitempty"`
+}
+
+func (h *admissionReviewHeader) review() *v1beta1.AdmissionReview {
+	ar := &v1beta1.AdmissionReview{TypeMeta: h.TypeMeta}
+	if h.Request != nil {
+		ar.Request = &v1beta1.AdmissionRequest{UID: h.Request.UID}
+	}
+	return ar
+}
+
+// failedAdmissionReview returns an AdmissionReview that rejects received with err,
+// the apiVersion and request.uid of received are kept, apiVersion defaults to admission.k8s.io/v1beta1
+func failedAdmissionReview(received *v1beta1.AdmissionReview, err error) *v1beta1.AdmissionReview {
+	ar := &v1beta1.AdmissionReview{
+		TypeMeta: v1.TypeMeta{APIVersion: admissionV1beta1, Kind: "AdmissionReview"},
+		Response: toAdmissionResponse(err),
+	}
+	if received == nil {
+		return ar
+	}
+	if received.APIVersion != "" {
+		ar.APIVersion = received.APIVersion
+	}
+	if received.Kind != "" {
+		ar.Kind = received.Kind
+	}
+	if received.Request != nil {
+		ar.Response.UID = received.Request.UID
+	}
+	return ar
+}
+
 func toAdmissionResponse(err error) *v1beta1.AdmissionResponse {
 	if err == nil {
 		return &v1beta1.AdmissionResponse{Allowed: true}
@@ -167,7 +296,7 @@ func validate(requestAdmissionReview *v1beta1.AdmissionReview,
 	createFunc admitFunc,
 	updateFunc admitFunc,
 	deleteFunc admitFunc) *v1beta1.AdmissionReview {
-	responseAdmissionReview := &v1beta1.AdmissionReview{}
+	responseAdmissionReview := &v1beta1.AdmissionReview{TypeMeta: requestAdmissionReview.TypeMeta}
 	switch requestAdmissionReview.Request.Operation {
 	case v1beta1.Create:
 		responseAdmissionReview.Response = createFunc(requestAdmissionReview)
@@ -183,7 +312,7 @@ func validate(requestAdmissionReview *v1beta1.AdmissionReview,
 }
 
 func mutate(requestAdmissionReview *v1beta1.AdmissionReview, mutateFunc admitFunc) *v1beta1.AdmissionReview {
-	responseAdmissionReview := &v1beta1.AdmissionReview{}
+	responseAdmissionReview := &v1beta1.AdmissionReview{TypeMeta: requestAdmissionReview.TypeMeta}
 	responseAdmissionReview.Response = mutateFunc(requestAdmissionReview)
 	responseAdmissionReview.Response.UID = requestAdmissionReview.Request.UID
 	return responseAdmissionReview
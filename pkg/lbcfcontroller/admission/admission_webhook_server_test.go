@@ -0,0 +1,109 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package admission
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func serveTestAdmissionReview(t *testing.T, body string, admit admitFunc) *v1beta1.AdmissionReview {
+	httpReq := httptest.NewRequest("POST", "/validate", strings.NewReader(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	serveValidate(restful.NewRequest(httpReq), restful.NewResponse(recorder), admit, admit, admit)
+
+	ar := &v1beta1.AdmissionReview{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), ar); err != nil {
+		t.Fatalf("decode response failed: %v, body: %s", err, recorder.Body.String())
+	}
+	return ar
+}
+
+func TestParseAdmissionReview(t *testing.T) {
+	cases := []struct {
+		name          string
+		body          string
+		expectVersion string
+		expectUID     types.UID
+		expectAllowed bool
+	}{
+		{
+			name:          "v1",
+			body:          `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{"uid":"uid-1","operation":"CREATE"}}`,
+			expectVersion: admissionV1,
+			expectUID:     "uid-1",
+			expectAllowed: true,
+		},
+		{
+			name:          "v1beta1",
+			body:          `{"apiVersion":"admission.k8s.io/v1beta1","kind":"AdmissionReview","request":{"uid":"uid-2","operation":"CREATE"}}`,
+			expectVersion: admissionV1beta1,
+			expectUID:     "uid-2",
+			expectAllowed: true,
+		},
+		{
+			name:          "decode-failure",
+			body:          `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{"uid":"uid-3","operation":1}}`,
+			expectVersion: admissionV1,
+			expectUID:     "uid-3",
+		},
+		{
+			name:          "invalid-json",
+			body:          `{"apiVersion":`,
+			expectVersion: admissionV1beta1,
+		},
+		{
+			name:          "unsupported-version",
+			body:          `{"apiVersion":"admission.k8s.io/v2","kind":"AdmissionReview","request":{"uid":"uid-4","operation":"CREATE"}}`,
+			expectVersion: "admission.k8s.io/v2",
+			expectUID:     "uid-4",
+		},
+		{
+			name:          "nil-request",
+			body:          `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview"}`,
+			expectVersion: admissionV1,
+		},
+	}
+	allow := func(*v1beta1.AdmissionReview) *v1beta1.AdmissionResponse { return toAdmissionResponse(nil) }
+	for _, c := range cases {
+		ar := serveTestAdmissionReview(t, c.body, allow)
+		if ar.APIVersion != c.expectVersion || ar.Kind != "AdmissionReview" {
+			t.Errorf("case %s: expect %s AdmissionReview, got %s %s", c.name, c.expectVersion, ar.APIVersion, ar.Kind)
+		}
+		if ar.Response == nil {
+			t.Errorf("case %s: expect response", c.name)
+			continue
+		}
+		if ar.Response.UID != c.expectUID {
+			t.Errorf("case %s: expect uid %q, got %q", c.name, c.expectUID, ar.Response.UID)
+		}
+		if ar.Response.Allowed != c.expectAllowed {
+			t.Errorf("case %s: expect allowed %v, got %v", c.name, c.expectAllowed, ar.Response.Allowed)
+		}
+		if !c.expectAllowed && (ar.Response.Result == nil || ar.Response.Result.Message == "") {
+			t.Errorf("case %s: expect a message for rejected request", c.name)
+		}
+	}
+}
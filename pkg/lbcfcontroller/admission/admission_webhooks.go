@@ -114,7 +114,9 @@ func (a *Admitter) MutateDriver(ar *admission.AdmissionReview) *admission.Admiss
 	}
 
 	dPatch := &driverPatch{obj: obj}
-	dPatch.setWebhook()
+	if util.GetProtocolVersion(obj) == lbcfapi.ProtocolVersionV1 {
+		dPatch.setWebhook()
+	}
 
 	p, err := json.Marshal(dPatch.patch())
 	if err != nil {
@@ -181,6 +183,12 @@ func (a *Admitter) ValidateLoadBalancerCreate(ar *admission.AdmissionReview) *ad
 			fmt.Errorf("driver %q is deleting, all LoadBalancer creating operation for that dirver is denied",
 				lb.Spec.LBDriver))
 	}
+	if lb.Spec.Import && !util.DriverImplements(driver, webhooks.ImportLoadBalancer) {
+		return toAdmissionResponse(fmt.Errorf("driver %q does not support importing load balancers", lb.Spec.LBDriver))
+	}
+	if !util.DriverImplements(driver, webhooks.ValidateLoadBalancer) {
+		return toAdmissionResponse(nil)
+	}
 	req := &webhooks.ValidateLoadBalancerRequest{
 		LBSpec:     lb.Spec.LBSpec,
 		Operation:  webhooks.OperationCreate,
@@ -222,6 +230,9 @@ func (a *Admitter) ValidateLoadBalancerUpdate(ar *admission.AdmissionReview) *ad
 		return toAdmissionResponse(fmt.Errorf("retrieve driver %s/%s failed: %v",
 			driverNamespace, curObj.Spec.LBDriver, err))
 	}
+	if !util.DriverImplements(driver, webhooks.ValidateLoadBalancer) {
+		return toAdmissionResponse(nil)
+	}
 
 	req := &webhooks.ValidateLoadBalancerRequest{
 		LBSpec:        curObj.Spec.LBSpec,
@@ -326,7 +337,7 @@ func (a *Admitter) ValidateBackendGroupCreate(ar *admission.AdmissionReview) *ad
 		return toAdmissionResponse(fmt.Errorf("%s", errList.ToAggregate().Error()))
 	}
 
-	lb, err := a.lbLister.LoadBalancers(bg.Namespace).Get(bg.Spec.LBName)
+	lb, err := a.lbLister.LoadBalancers(util.GetLBNamespace(bg)).Get(bg.Spec.LBName)
 	if err != nil {
 		return toAdmissionResponse(fmt.Errorf("loadbalancer not found, " +
 			"LoadBalancer must be created before BackendGroup"))
@@ -334,7 +345,11 @@ func (a *Admitter) ValidateBackendGroupCreate(ar *admission.AdmissionReview) *ad
 	if lb.DeletionTimestamp != nil {
 		return toAdmissionResponse(fmt.Errorf("operation denied: loadbalancer %q is deleting", lb.Name))
 	}
-	driverNamespace := util.GetDriverNamespace(lb.Spec.LBDriver, bg.Namespace)
+	if !util.IsNamespaceAllowed(lb, bg.Namespace) {
+		return toAdmissionResponse(fmt.Errorf("operation denied: loadbalancer %s/%s is not allowed in namespace %q",
+			lb.Namespace, lb.Name, bg.Namespace))
+	}
+	driverNamespace := util.GetDriverNamespace(lb.Spec.LBDriver, lb.Namespace)
 	driver, err := a.driverLister.LoadBalancerDrivers(driverNamespace).Get(lb.Spec.LBDriver)
 	if err != nil {
 		return toAdmissionResponse(
@@ -349,6 +364,9 @@ func (a *Admitter) ValidateBackendGroupCreate(ar *admission.AdmissionReview) *ad
 			fmt.Errorf("driver %q is deleting, all BackendGroup creating operation for that dirver is denied",
 				lb.Spec.LBDriver))
 	}
+	if !util.DriverImplements(driver, webhooks.ValidateBackend) {
+		return toAdmissionResponse(nil)
+	}
 	req := &webhooks.ValidateBackendRequest{
 		BackendType: string(util.GetBackendType(bg)),
 		LBInfo:      lb.Status.LBInfo,
@@ -386,17 +404,24 @@ func (a *Admitter) ValidateBackendGroupUpdate(ar *admission.AdmissionReview) *ad
 		return toAdmissionResponse(fmt.Errorf("%s", errList.ToAggregate().Error()))
 	}
 
-	lb, err := a.lbLister.LoadBalancers(curObj.Namespace).Get(curObj.Spec.LBName)
+	lb, err := a.lbLister.LoadBalancers(util.GetLBNamespace(curObj)).Get(curObj.Spec.LBName)
 	if err != nil {
 		return toAdmissionResponse(
 			fmt.Errorf("loadbalancer not found, LoadBalancer must be created before BackendGroup"))
 	}
-	driverNamespace := util.GetDriverNamespace(lb.Spec.LBDriver, curObj.Namespace)
+	if !util.IsNamespaceAllowed(lb, curObj.Namespace) {
+		return toAdmissionResponse(fmt.Errorf("operation denied: loadbalancer %s/%s is not allowed in namespace %q",
+			lb.Namespace, lb.Name, curObj.Namespace))
+	}
+	driverNamespace := util.GetDriverNamespace(lb.Spec.LBDriver, lb.Namespace)
 	driver, err := a.driverLister.LoadBalancerDrivers(driverNamespace).Get(lb.Spec.LBDriver)
 	if err != nil {
 		return toAdmissionResponse(
 			fmt.Errorf("retrieve driver %s/%s failed: %v", driverNamespace, lb.Spec.LBDriver, err))
 	}
+	if !util.DriverImplements(driver, webhooks.ValidateBackend) {
+		return toAdmissionResponse(nil)
+	}
 
 	req := &webhooks.ValidateBackendRequest{
 		BackendType:   string(util.GetBackendType(curObj)),
@@ -444,7 +469,7 @@ func (a *Admitter) listBackendByDriver(driverName string, driverNamespace string
 	}
 	var ret []*lbcfapi.BackendRecord
 	for _, r := range recordList {
-		if driverNamespace != metav1.NamespaceSystem && r.Namespace != driverNamespace {
+		if driverNamespace != metav1.NamespaceSystem && util.GetBackendLBNamespace(r) != driverNamespace {
 			continue
 		}
 		if r.Spec.LBDriver == driverName {
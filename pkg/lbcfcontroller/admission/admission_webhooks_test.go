@@ -0,0 +1,120 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package admission
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
+
+	admission "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newTestDriver(version lbcfapi.ProtocolVersion, hooks ...string) *lbcfapi.LoadBalancerDriver {
+	driver := &lbcfapi.LoadBalancerDriver{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-driver",
+			Namespace: "default",
+		},
+		Spec: lbcfapi.LoadBalancerDriverSpec{
+			DriverType:      string(lbcfapi.WebhookDriver),
+			Url:             "http://driver.default.svc",
+			ProtocolVersion: version,
+		},
+	}
+	for _, h := range hooks {
+		driver.Spec.Webhooks = append(driver.Spec.Webhooks, lbcfapi.WebhookConfig{
+			Name:    h,
+			Timeout: lbcfapi.Duration{Duration: 10 * time.Second},
+		})
+	}
+	return driver
+}
+
+func newTestReview(t *testing.T, operation admission.Operation, obj, old runtime.Object) *admission.AdmissionReview {
+	ar := &admission.AdmissionReview{
+		Request: &admission.AdmissionRequest{Operation: operation},
+	}
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("marshal object failed: %v", err)
+	}
+	ar.Request.Object.Raw = raw
+	if old != nil {
+		raw, err := json.Marshal(old)
+		if err != nil {
+			t.Fatalf("marshal old object failed: %v", err)
+		}
+		ar.Request.OldObject.Raw = raw
+	}
+	return ar
+}
+
+func TestMutateDriverDoesNotAddOptionalWebhooks(t *testing.T) {
+	a := &Admitter{}
+	rsp := a.MutateDriver(newTestReview(t, admission.Create, newTestDriver(""), nil))
+	if !rsp.Allowed {
+		t.Fatalf("expect allowed, got %+v", rsp.Result)
+	}
+	var patches []Patch
+	if err := json.Unmarshal(rsp.Patch, &patches); err != nil {
+		t.Fatalf("decode patch failed: %v", err)
+	}
+	added := make(map[string]bool)
+	for _, p := range patches {
+		raw, _ := json.Marshal(p.Value)
+		wh := lbcfapi.WebhookConfig{}
+		if err := json.Unmarshal(raw, &wh); err == nil && wh.Name != "" {
+			added[wh.Name] = true
+		}
+	}
+	for known := range webhooks.KnownWebhooks {
+		if !added[known] {
+			t.Errorf("expect webhook %s to be defaulted", known)
+		}
+	}
+	for optional := range webhooks.OptionalWebhooks {
+		if added[optional] {
+			t.Errorf("expect optional webhook %s not to be defaulted", optional)
+		}
+	}
+}
+
+func TestValidateDriverUpdateWithoutImportLoadBalancer(t *testing.T) {
+	old := newTestDriver("", webhooks.KnownWebhooks.List()...)
+	cur := old.DeepCopy()
+	cur.Labels = map[string]string{"updated": "true"}
+
+	a := &Admitter{}
+	if rsp := a.ValidateDriverUpdate(newTestReview(t, admission.Update, cur, old)); !rsp.Allowed {
+		t.Fatalf("expect v1 driver without importLoadBalancer to be allowed, got %+v", rsp.Result)
+	}
+
+	cur.Spec.Webhooks = append(cur.Spec.Webhooks, lbcfapi.WebhookConfig{
+		Name:    webhooks.ImportLoadBalancer,
+		Timeout: lbcfapi.Duration{Duration: 10 * time.Second},
+	})
+	if rsp := a.ValidateDriverUpdate(newTestReview(t, admission.Update, cur, old)); !rsp.Allowed {
+		t.Fatalf("expect driver declaring importLoadBalancer to be allowed, got %+v", rsp.Result)
+	}
+}
@@ -0,0 +1,583 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package admission
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"tkestack.io/lb-controlling-framework/cmd/lbcf-controller/app/config"
+
+	apicorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/klog"
+)
+
+const (
+	// certCheckPeriod is how often the serving certificate is reloaded
+	certCheckPeriod = time.Minute
+	// certRotateBefore is how long before expiration the self-signed serving certificate is rotated
+	certRotateBefore = 30 * 24 * time.Hour
+	// servingCertValidity is the validity of certificates signed by cert.NewSignedCert
+	servingCertValidity = 365 * 24 * time.Hour
+	// caOverlapPeriod is how long both the old and the new CA are in caBundle before and after
+	// the serving certificate is switched, so that the apiserver trusts whichever certificate a replica serves
+	caOverlapPeriod = 10 * certCheckPeriod
+
+	secretKeyCACert          = "ca.crt"
+	secretKeyCAKey           = "ca.key"
+	secretKeyCert            = "tls.crt"
+	secretKeyKey             = "tls.key"
+	secretKeyNextCACert      = "next-ca.crt"
+	secretKeyNextCAKey       = "next-ca.key"
+	secretKeyPreviousCACert  = "previous-ca.crt"
+	secretKeyPreviousCAUntil = "previous-ca.until"
+
+	apiextensionsGroup = "apiextensions.k8s.io"
+)
+
+// lbcfCRDs are CRDs whose conversion webhook is served by the admit webhook server
+var lbcfCRDs = []string{
+	"loadbalancerdrivers.lbcf.tke.cloud.tencent.com",
+	"loadbalancers.lbcf.tke.cloud.tencent.com",
+	"backendgroups.lbcf.tke.cloud.tencent.com",
+	"backendrecords.lbcf.tke.cloud.tencent.com",
+}
+
+// certProvider provides the serving certificate of admit webhook server
+type certProvider interface {
+	// sync loads the latest certificate, it is called periodically
+	sync() error
+	// GetCertificate is used as tls.Config.GetCertificate
+	GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error)
+	// caBundle returns the PEM encoded CA that signs the serving certificate, nil if unknown
+	caBundle() []byte
+}
+
+type certStore struct {
+	mu   sync.RWMutex
+	cert *tls.Certificate
+	ca   []byte
+}
+
+func (s *certStore) set(cert tls.Certificate, ca []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cert = &cert
+	s.ca = ca
+}
+
+func (s *certStore) caBundle() []byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ca
+}
+
+// GetCertificate implements certProvider
+func (s *certStore) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cert == nil {
+		return nil, fmt.Errorf("serving certificate is not loaded")
+	}
+	return s.cert, nil
+}
+
+func newFileCertProvider(crtFile string, keyFile string, caFile string) *fileCertProvider {
+	return &fileCertProvider{
+		crtFile: crtFile,
+		keyFile: keyFile,
+		caFile:  caFile,
+	}
+}
+
+// fileCertProvider loads certificate from --server-crt and --server-key.
+// Files are reloaded periodically, so that a certificate updated in the mounted Secret takes effect without restart.
+type fileCertProvider struct {
+	certStore
+	crtFile string
+	keyFile string
+	caFile  string
+}
+
+func (p *fileCertProvider) sync() error {
+	c, err := tls.LoadX509KeyPair(p.crtFile, p.keyFile)
+	if err != nil {
+		return err
+	}
+	var ca []byte
+	if p.caFile != "" {
+		if ca, err = ioutil.ReadFile(p.caFile); err != nil {
+			return err
+		}
+	}
+	p.set(c, ca)
+	return nil
+}
+
+func newSelfSignedCertProvider(client kubernetes.Interface, cfg *config.Config) *selfSignedCertProvider {
+	return &selfSignedCertProvider{
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+// selfSignedCertProvider generates a self-signed CA and a serving certificate signed by it.
+// The certificates are stored in a Secret shared by all replicas of lbcf-controller,
+// and the CA is patched into the caBundle of webhooks served by admit webhook server.
+type selfSignedCertProvider struct {
+	certStore
+	client kubernetes.Interface
+	cfg    *config.Config
+
+	patchedCA []byte
+}
+
+func (p *selfSignedCertProvider) sync() error {
+	secret, err := p.client.CoreV1().Secrets(p.cfg.WebhookServiceNamespace).Get(p.cfg.CertSecretName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		secret = nil
+	} else if err != nil {
+		return err
+	}
+
+	var bundle *certBundle
+	if secret != nil {
+		bundle, err = parseCertBundle(secret.Data)
+		if err != nil {
+			klog.Warningf("invalid certificate in Secret %s/%s, regenerate: %v", secret.Namespace, secret.Name, err)
+		}
+	}
+	now := time.Now()
+	bundle, changed, err := p.rotate(bundle, now)
+	if err != nil {
+		return err
+	}
+	if changed {
+		if err := p.saveCertBundle(secret, bundle); err != nil {
+			return err
+		}
+		klog.Infof("self-signed certificate saved, serving certificate expires at %s", bundle.cert.NotAfter)
+	}
+
+	servingCert, err := tls.X509KeyPair(bundle.certPEM, bundle.keyPEM)
+	if err != nil {
+		return err
+	}
+	caBundle := bundle.caBundle(now)
+	p.set(servingCert, caBundle)
+
+	if !bytes.Equal(p.patchedCA, caBundle) {
+		if err := p.patchCABundle(caBundle); err != nil {
+			return err
+		}
+		p.patchedCA = caBundle
+	}
+	return nil
+}
+
+func (p *selfSignedCertProvider) serviceDNSNames() []string {
+	svc := p.cfg.WebhookServiceName
+	ns := p.cfg.WebhookServiceNamespace
+	return []string{
+		fmt.Sprintf("%s.%s.svc", svc, ns),
+		fmt.Sprintf("%s.%s.svc.cluster.local", svc, ns),
+		fmt.Sprintf("%s.%s", svc, ns),
+		svc,
+	}
+}
+
+// needRotate returns true if the serving certificate is about to expire or no longer matches the Service
+func (p *selfSignedCertProvider) needRotate(b *certBundle, now time.Time) bool {
+	if now.Add(certRotateBefore).After(b.cert.NotAfter) {
+		return true
+	}
+	return b.cert.VerifyHostname(p.serviceDNSNames()[0]) != nil
+}
+
+// rotate returns the certificates that should be in use at now, and whether they differ from old.
+//
+// A CA that does not outlive a new serving certificate is replaced in steps:
+// a new CA is generated and published in caBundle together with the current one,
+// the serving certificate is switched to the new CA after caOverlapPeriod,
+// and the old CA is removed from caBundle after another caOverlapPeriod.
+func (p *selfSignedCertProvider) rotate(old *certBundle, now time.Time) (*certBundle, bool, error) {
+	if old == nil {
+		caCert, caKey, err := newCA()
+		if err != nil {
+			return nil, false, err
+		}
+		b := &certBundle{caCert: caCert, caKey: caKey}
+		b.caCertPEM, b.caKeyPEM = cert.EncodeCertPEM(caCert), cert.EncodePrivateKeyPEM(caKey)
+		if err := p.signServingCert(b); err != nil {
+			return nil, false, err
+		}
+		return b, true, nil
+	}
+
+	b := *old
+	changed := false
+	if b.previousCACert != nil && !now.Before(b.previousCAUntil) {
+		b.previousCACert, b.previousCACertPEM = nil, nil
+		changed = true
+	}
+
+	if b.nextCACert != nil && now.Sub(b.nextCACert.NotBefore) >= caOverlapPeriod {
+		b.previousCACert, b.previousCACertPEM = b.caCert, b.caCertPEM
+		b.previousCAUntil = now.Add(caOverlapPeriod)
+		b.caCert, b.caKey, b.caCertPEM, b.caKeyPEM = b.nextCACert, b.nextCAKey, b.nextCACertPEM, b.nextCAKeyPEM
+		b.nextCACert, b.nextCAKey, b.nextCACertPEM, b.nextCAKeyPEM = nil, nil, nil, nil
+		if err := p.signServingCert(&b); err != nil {
+			return nil, false, err
+		}
+		return &b, true, nil
+	}
+
+	if b.nextCACert == nil && !now.Add(servingCertValidity).Before(b.caCert.NotAfter) {
+		caCert, caKey, err := newCA()
+		if err != nil {
+			return nil, false, err
+		}
+		b.nextCACert, b.nextCAKey = caCert, caKey
+		b.nextCACertPEM, b.nextCAKeyPEM = cert.EncodeCertPEM(caCert), cert.EncodePrivateKeyPEM(caKey)
+		changed = true
+	}
+
+	if p.needRotate(&b, now) {
+		if err := p.signServingCert(&b); err != nil {
+			return nil, false, err
+		}
+		changed = true
+	}
+	return &b, changed, nil
+}
+
+func newCA() (*x509.Certificate, *rsa.PrivateKey, error) {
+	caKey, err := cert.NewPrivateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	caCert, err := cert.NewSelfSignedCACert(cert.Config{CommonName: "lbcf-controller-ca"}, caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return caCert, caKey, nil
+}
+
+// signServingCert generates a new serving certificate signed by the CA in b
+func (p *selfSignedCertProvider) signServingCert(b *certBundle) error {
+	key, err := cert.NewPrivateKey()
+	if err != nil {
+		return err
+	}
+	dnsNames := p.serviceDNSNames()
+	servingCert, err := cert.NewSignedCert(cert.Config{
+		CommonName: dnsNames[0],
+		AltNames:   cert.AltNames{DNSNames: dnsNames},
+		Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, key, b.caCert, b.caKey)
+	if err != nil {
+		return err
+	}
+	b.cert = servingCert
+	b.certPEM, b.keyPEM = cert.EncodeCertPEM(servingCert), cert.EncodePrivateKeyPEM(key)
+	return nil
+}
+
+// saveCertBundle creates or updates the Secret, it fails if another replica modified the Secret in the meantime,
+// and the certificate saved by that replica will be used in the next sync
+func (p *selfSignedCertProvider) saveCertBundle(secret *apicorev1.Secret, b *certBundle) error {
+	data := map[string][]byte{
+		secretKeyCACert: b.caCertPEM,
+		secretKeyCAKey:  b.caKeyPEM,
+		secretKeyCert:   b.certPEM,
+		secretKeyKey:    b.keyPEM,
+	}
+	if b.nextCACert != nil {
+		data[secretKeyNextCACert] = b.nextCACertPEM
+		data[secretKeyNextCAKey] = b.nextCAKeyPEM
+	}
+	if b.previousCACert != nil {
+		data[secretKeyPreviousCACert] = b.previousCACertPEM
+		data[secretKeyPreviousCAUntil] = []byte(b.previousCAUntil.UTC().Format(time.RFC3339))
+	}
+	if secret == nil {
+		_, err := p.client.CoreV1().Secrets(p.cfg.WebhookServiceNamespace).Create(&apicorev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      p.cfg.CertSecretName,
+				Namespace: p.cfg.WebhookServiceNamespace,
+			},
+			Type: apicorev1.SecretTypeOpaque,
+			Data: data,
+		})
+		return err
+	}
+	cpy := secret.DeepCopy()
+	cpy.Data = data
+	_, err := p.client.CoreV1().Secrets(cpy.Namespace).Update(cpy)
+	return err
+}
+
+// patchCABundle sets caBundle of all webhooks served by admit webhook server
+func (p *selfSignedCertProvider) patchCABundle(caBundle []byte) error {
+	// webhook configurations and CRDs are accessed as raw json in the version served by the apiserver
+	restClient := p.client.AdmissionregistrationV1beta1().RESTClient()
+	webhookVersion, err := webhookConfigVersion(p.client.Discovery())
+	if err != nil {
+		return err
+	}
+	if err := patchWebhookCABundle(restClient, webhookVersion, validatingWebhookConfigKind,
+		p.cfg.ValidatingWebhookConfigName, false, caBundle); err != nil {
+		return err
+	}
+	if err := patchWebhookCABundle(restClient, webhookVersion, mutatingWebhookConfigKind,
+		p.cfg.MutatingWebhookConfigName, true, caBundle); err != nil {
+		return err
+	}
+
+	// the vendored apiextensions types have no conversion webhook, so CRDs are patched as raw json
+	crdVersion, err := serverGroupVersion(p.client.Discovery(), apiextensionsGroup, "v1", "v1beta1")
+	if err != nil {
+		return err
+	}
+	crdPath := fmt.Sprintf("/apis/%s/%s/customresourcedefinitions", apiextensionsGroup, crdVersion)
+	for _, crdName := range lbcfCRDs {
+		raw, err := restClient.Get().AbsPath(crdPath, crdName).DoRaw()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		crd := struct {
+			Spec struct {
+				Conversion *struct {
+					Strategy string `json:"strategy"`
+				} `json:"conversion"`
+			} `json:"spec"`
+		}{}
+		if err := json.Unmarshal(raw, &crd); err != nil {
+			return err
+		}
+		if crd.Spec.Conversion == nil || crd.Spec.Conversion.Strategy != "Webhook" {
+			continue
+		}
+		patch := crdCABundlePatch(crdVersion, caBundle)
+		if err := restClient.Patch(types.MergePatchType).AbsPath(crdPath, crdName).Body(patch).Do().Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func patchWebhookCABundle(restClient rest.Interface, version string, kind string, name string, mutating bool, caBundle []byte) error {
+	path := webhookConfigPath(version, mutating)
+	raw, err := restClient.Get().AbsPath(path, name).DoRaw()
+	if errors.IsNotFound(err) {
+		klog.Warningf("%s %s not found, skip patching caBundle", kind, name)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	existing := &webhookConfiguration{}
+	if err := json.Unmarshal(raw, existing); err != nil {
+		return err
+	}
+	if len(existing.Webhooks) == 0 {
+		return nil
+	}
+	var names []string
+	for _, wh := range existing.Webhooks {
+		names = append(names, wh.Name)
+	}
+	return restClient.Patch(types.StrategicMergePatchType).AbsPath(path, name).
+		Body(webhookCABundlePatch(names, caBundle)).Do().Error()
+}
+
+// serverGroupVersion returns the first of versions served for group by the apiserver
+func serverGroupVersion(client discovery.DiscoveryInterface, group string, versions ...string) (string, error) {
+	groups, err := client.ServerGroups()
+	if err != nil {
+		return "", err
+	}
+	served := sets.NewString()
+	for _, g := range groups.Groups {
+		if g.Name != group {
+			continue
+		}
+		for _, v := range g.Versions {
+			served.Insert(v.Version)
+		}
+	}
+	for _, v := range versions {
+		if served.Has(v) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("none of %v is served for API group %s", versions, group)
+}
+
+// crdCABundlePatch returns a json merge patch that sets caBundle of CRD conversion webhook,
+// the caBundle is at spec.conversion.webhook.clientConfig in v1 and at spec.conversion.webhookClientConfig in v1beta1
+func crdCABundlePatch(crdVersion string, caBundle []byte) []byte {
+	conversion := map[string]interface{}{
+		"webhookClientConfig": map[string]interface{}{
+			"caBundle": caBundle,
+		},
+	}
+	if crdVersion == "v1" {
+		conversion = map[string]interface{}{
+			"webhook": map[string]interface{}{
+				"clientConfig": map[string]interface{}{
+					"caBundle": caBundle,
+				},
+			},
+		}
+	}
+	patch, _ := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"conversion": conversion,
+		},
+	})
+	return patch
+}
+
+// webhookCABundlePatch returns a strategic merge patch, webhooks are merged by name
+func webhookCABundlePatch(webhookNames []string, caBundle []byte) []byte {
+	var webhooks []interface{}
+	for _, name := range webhookNames {
+		webhooks = append(webhooks, map[string]interface{}{
+			"name": name,
+			"clientConfig": map[string]interface{}{
+				"caBundle": caBundle,
+			},
+		})
+	}
+	patch, _ := json.Marshal(map[string]interface{}{
+		"webhooks": webhooks,
+	})
+	return patch
+}
+
+type certBundle struct {
+	caCertPEM []byte
+	caKeyPEM  []byte
+	certPEM   []byte
+	keyPEM    []byte
+
+	caCert *x509.Certificate
+	caKey  *rsa.PrivateKey
+	cert   *x509.Certificate
+
+	// nextCA is published in caBundle, and will sign the serving certificate after caOverlapPeriod
+	nextCACertPEM []byte
+	nextCAKeyPEM  []byte
+	nextCACert    *x509.Certificate
+	nextCAKey     *rsa.PrivateKey
+
+	// previousCA signed the last serving certificate, it is kept in caBundle until previousCAUntil
+	previousCACertPEM []byte
+	previousCACert    *x509.Certificate
+	previousCAUntil   time.Time
+}
+
+// caBundle returns all CAs that should be trusted at now
+func (b *certBundle) caBundle(now time.Time) []byte {
+	caBundle := append([]byte{}, b.caCertPEM...)
+	if b.nextCACert != nil {
+		caBundle = append(caBundle, b.nextCACertPEM...)
+	}
+	if b.previousCACert != nil && now.Before(b.previousCAUntil) {
+		caBundle = append(caBundle, b.previousCACertPEM...)
+	}
+	return caBundle
+}
+
+func parseCertBundle(data map[string][]byte) (*certBundle, error) {
+	b := &certBundle{
+		caCertPEM: data[secretKeyCACert],
+		caKeyPEM:  data[secretKeyCAKey],
+		certPEM:   data[secretKeyCert],
+		keyPEM:    data[secretKeyKey],
+	}
+	caCerts, err := cert.ParseCertsPEM(b.caCertPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s failed: %v", secretKeyCACert, err)
+	}
+	b.caCert = caCerts[0]
+	if b.caKey, err = parseRSAKey(secretKeyCAKey, b.caKeyPEM); err != nil {
+		return nil, err
+	}
+	certs, err := cert.ParseCertsPEM(b.certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s failed: %v", secretKeyCert, err)
+	}
+	b.cert = certs[0]
+	if _, err := tls.X509KeyPair(b.certPEM, b.keyPEM); err != nil {
+		return nil, err
+	}
+
+	if len(data[secretKeyNextCACert]) > 0 {
+		b.nextCACertPEM, b.nextCAKeyPEM = data[secretKeyNextCACert], data[secretKeyNextCAKey]
+		nextCACerts, err := cert.ParseCertsPEM(b.nextCACertPEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s failed: %v", secretKeyNextCACert, err)
+		}
+		b.nextCACert = nextCACerts[0]
+		if b.nextCAKey, err = parseRSAKey(secretKeyNextCAKey, b.nextCAKeyPEM); err != nil {
+			return nil, err
+		}
+	}
+	if len(data[secretKeyPreviousCACert]) > 0 {
+		b.previousCACertPEM = data[secretKeyPreviousCACert]
+		previousCACerts, err := cert.ParseCertsPEM(b.previousCACertPEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s failed: %v", secretKeyPreviousCACert, err)
+		}
+		b.previousCACert = previousCACerts[0]
+		if b.previousCAUntil, err = time.Parse(time.RFC3339, string(data[secretKeyPreviousCAUntil])); err != nil {
+			return nil, fmt.Errorf("parse %s failed: %v", secretKeyPreviousCAUntil, err)
+		}
+	}
+	return b, nil
+}
+
+func parseRSAKey(secretKey string, keyPEM []byte) (*rsa.PrivateKey, error) {
+	key, err := cert.ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s failed: %v", secretKey, err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a RSA private key", secretKey)
+	}
+	return rsaKey, nil
+}
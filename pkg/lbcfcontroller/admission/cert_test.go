@@ -0,0 +1,214 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"tkestack.io/lb-controlling-framework/cmd/lbcf-controller/app/config"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestSelfSignedCertProvider() *selfSignedCertProvider {
+	return newSelfSignedCertProvider(fake.NewSimpleClientset(), &config.Config{
+		WebhookServiceName:      "lbcf-controller",
+		WebhookServiceNamespace: "kube-system",
+		CertSecretName:          "lbcf-controller-cert",
+	})
+}
+
+func TestServerGroupVersion(t *testing.T) {
+	cases := []struct {
+		name          string
+		groupVersions []string
+		expectVersion string
+		expectErr     bool
+	}{
+		{
+			name:          "v1-and-v1beta1",
+			groupVersions: []string{"apiextensions.k8s.io/v1beta1", "apiextensions.k8s.io/v1"},
+			expectVersion: "v1",
+		},
+		{
+			name:          "v1beta1-only",
+			groupVersions: []string{"apiextensions.k8s.io/v1beta1"},
+			expectVersion: "v1beta1",
+		},
+		{
+			name:          "not-served",
+			groupVersions: []string{"apps/v1"},
+			expectErr:     true,
+		},
+	}
+	for _, c := range cases {
+		client := fake.NewSimpleClientset()
+		for _, gv := range c.groupVersions {
+			client.Resources = append(client.Resources, &metav1.APIResourceList{GroupVersion: gv})
+		}
+		version, err := serverGroupVersion(client.Discovery(), apiextensionsGroup, "v1", "v1beta1")
+		if c.expectErr {
+			if err == nil {
+				t.Errorf("case %s, expect error", c.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %s, unexpected error: %v", c.name, err)
+		}
+		if version != c.expectVersion {
+			t.Errorf("case %s, expect %s, get %s", c.name, c.expectVersion, version)
+		}
+	}
+}
+
+func TestCRDCABundlePatch(t *testing.T) {
+	caBundle := []byte("ca")
+	patch := map[string]map[string]map[string]map[string]map[string][]byte{}
+	if err := json.Unmarshal(crdCABundlePatch("v1", caBundle), &patch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if get := patch["spec"]["conversion"]["webhook"]["clientConfig"]["caBundle"]; !bytes.Equal(get, caBundle) {
+		t.Errorf("v1 patch, expect caBundle %q, get %q", caBundle, get)
+	}
+
+	betaPatch := map[string]map[string]map[string]map[string][]byte{}
+	if err := json.Unmarshal(crdCABundlePatch("v1beta1", caBundle), &betaPatch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if get := betaPatch["spec"]["conversion"]["webhookClientConfig"]["caBundle"]; !bytes.Equal(get, caBundle) {
+		t.Errorf("v1beta1 patch, expect caBundle %q, get %q", caBundle, get)
+	}
+}
+
+func TestSelfSignedCertRotateCA(t *testing.T) {
+	p := newTestSelfSignedCertProvider()
+	b, changed, err := p.rotate(nil, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if !changed {
+		t.Fatalf("expect changed")
+	}
+	oldCA := b.caCert
+	if !bytes.Equal(b.caBundle(time.Now()), b.caCertPEM) {
+		t.Errorf("expect caBundle contains only the CA")
+	}
+
+	// the CA does not outlive a new serving certificate, a new CA is published but not used yet
+	caExpiring := oldCA.NotAfter.Add(-servingCertValidity / 2)
+	b, changed, err = p.rotate(b, caExpiring)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if !changed {
+		t.Fatalf("expect changed")
+	} else if b.nextCACert == nil {
+		t.Fatalf("expect next CA")
+	}
+	if err := b.cert.CheckSignatureFrom(oldCA); err != nil {
+		t.Errorf("expect serving certificate signed by the old CA: %v", err)
+	}
+	expectBundle := append(append([]byte{}, b.caCertPEM...), b.nextCACertPEM...)
+	if !bytes.Equal(b.caBundle(caExpiring), expectBundle) {
+		t.Errorf("expect caBundle contains the old and the next CA")
+	}
+	newCA := b.nextCACert
+
+	// the serving certificate is not switched within caOverlapPeriod
+	b, changed, err = p.rotate(b, newCA.NotBefore.Add(caOverlapPeriod-time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if changed {
+		t.Fatalf("expect unchanged within overlap period")
+	}
+
+	// the serving certificate is switched, the old CA is kept for another caOverlapPeriod
+	switchTime := newCA.NotBefore.Add(caOverlapPeriod)
+	b, changed, err = p.rotate(b, switchTime)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if !changed {
+		t.Fatalf("expect changed")
+	}
+	if b.nextCACert != nil || b.caCert != newCA || b.previousCACert != oldCA {
+		t.Fatalf("expect the next CA promoted")
+	}
+	if err := b.cert.CheckSignatureFrom(newCA); err != nil {
+		t.Errorf("expect serving certificate signed by the new CA: %v", err)
+	}
+	expectBundle = append(append([]byte{}, b.caCertPEM...), b.previousCACertPEM...)
+	if !bytes.Equal(b.caBundle(switchTime), expectBundle) {
+		t.Errorf("expect caBundle contains the new and the previous CA")
+	}
+
+	// the old CA is removed
+	b, changed, err = p.rotate(b, switchTime.Add(caOverlapPeriod))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if !changed {
+		t.Fatalf("expect changed")
+	}
+	if b.previousCACert != nil || !bytes.Equal(b.caBundle(switchTime.Add(caOverlapPeriod)), b.caCertPEM) {
+		t.Errorf("expect the previous CA removed")
+	}
+}
+
+func TestSelfSignedCertSaveAndParse(t *testing.T) {
+	p := newTestSelfSignedCertProvider()
+	b, _, err := p.rotate(nil, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	caExpiring := b.caCert.NotAfter.Add(-servingCertValidity / 2)
+	if b, _, err = p.rotate(b, caExpiring); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	switchTime := b.nextCACert.NotBefore.Add(caOverlapPeriod)
+	if b, _, err = p.rotate(b, switchTime); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.previousCACert == nil {
+		t.Fatalf("expect previous CA")
+	}
+	if err := p.saveCertBundle(nil, b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	secret, err := p.client.CoreV1().Secrets(p.cfg.WebhookServiceNamespace).Get(p.cfg.CertSecretName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsed, err := parseCertBundle(secret.Data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !parsed.previousCAUntil.Equal(b.previousCAUntil.Truncate(time.Second)) {
+		t.Errorf("expect previous CA until %s, get %s", b.previousCAUntil, parsed.previousCAUntil)
+	}
+	if !bytes.Equal(parsed.caBundle(switchTime), b.caBundle(switchTime)) {
+		t.Errorf("expect the same caBundle after parsing")
+	}
+	if _, changed, err := p.rotate(parsed, switchTime); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if changed {
+		t.Errorf("expect unchanged")
+	}
+}
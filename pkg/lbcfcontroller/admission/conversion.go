@@ -0,0 +1,192 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+
+	lbcfv1 "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1"
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+)
+
+// ConversionReview describes a conversion request/response, it is the same as
+// apiextensions.k8s.io/v1beta1 ConversionReview
+type ConversionReview struct {
+	v1.TypeMeta `json:",inline"`
+	Request     *ConversionRequest  `json:"request,omitempty"`
+	Response    *ConversionResponse `json:"response,omitempty"`
+}
+
+// ConversionRequest describes the conversion request parameters
+type ConversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+// ConversionResponse describes a conversion response
+type ConversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           v1.Status              `json:"result"`
+}
+
+// Convert implements the conversion webhook for all CRDs defined by LBCF
+func (s *Server) Convert(req *restful.Request, rsp *restful.Response) {
+	review := &ConversionReview{}
+	if err := req.ReadEntity(review); err != nil || review.Request == nil {
+		klog.Errorf("decode ConversionReview failed: %v", err)
+		review.Response = &ConversionResponse{
+			Result: v1.Status{Status: v1.StatusFailure, Message: fmt.Sprintf("decode ConversionReview failed: %v", err)},
+		}
+		responseConversion(review, rsp)
+		return
+	}
+	review.Response = convert(review.Request)
+	review.Request = nil
+	responseConversion(review, rsp)
+}
+
+func convert(req *ConversionRequest) *ConversionResponse {
+	rsp := &ConversionResponse{
+		UID:    req.UID,
+		Result: v1.Status{Status: v1.StatusSuccess},
+	}
+	for _, obj := range req.Objects {
+		converted, err := convertObject(obj.Raw, req.DesiredAPIVersion)
+		if err != nil {
+			rsp.ConvertedObjects = nil
+			rsp.Result = v1.Status{Status: v1.StatusFailure, Message: err.Error()}
+			return rsp
+		}
+		rsp.ConvertedObjects = append(rsp.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	return rsp
+}
+
+func convertObject(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	typeMeta := v1.TypeMeta{}
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, fmt.Errorf("decode object failed: %v", err)
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+	switch {
+	case typeMeta.APIVersion == lbcfapi.ApiVersion && desiredAPIVersion == lbcfv1.ApiVersion:
+		return convertFromV1beta1(raw, typeMeta.Kind)
+	case typeMeta.APIVersion == lbcfv1.ApiVersion && desiredAPIVersion == lbcfapi.ApiVersion:
+		return convertToV1beta1(raw, typeMeta.Kind)
+	}
+	return nil, fmt.Errorf("unsupported conversion from %s to %s", typeMeta.APIVersion, desiredAPIVersion)
+}
+
+func convertFromV1beta1(raw []byte, kind string) ([]byte, error) {
+	var out interface{}
+	switch kind {
+	case "LoadBalancer":
+		in := &lbcfapi.LoadBalancer{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return nil, err
+		}
+		converted := &lbcfv1.LoadBalancer{}
+		lbcfv1.Convert_v1beta1_LoadBalancer_To_v1_LoadBalancer(in, converted)
+		out = converted
+	case "BackendGroup":
+		in := &lbcfapi.BackendGroup{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return nil, err
+		}
+		converted := &lbcfv1.BackendGroup{}
+		lbcfv1.Convert_v1beta1_BackendGroup_To_v1_BackendGroup(in, converted)
+		out = converted
+	case "LoadBalancerDriver":
+		in := &lbcfapi.LoadBalancerDriver{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return nil, err
+		}
+		converted := &lbcfv1.LoadBalancerDriver{}
+		lbcfv1.Convert_v1beta1_LoadBalancerDriver_To_v1_LoadBalancerDriver(in, converted)
+		out = converted
+	case "BackendRecord":
+		in := &lbcfapi.BackendRecord{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return nil, err
+		}
+		converted := &lbcfv1.BackendRecord{}
+		lbcfv1.Convert_v1beta1_BackendRecord_To_v1_BackendRecord(in, converted)
+		out = converted
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", kind)
+	}
+	return json.Marshal(out)
+}
+
+func convertToV1beta1(raw []byte, kind string) ([]byte, error) {
+	var out interface{}
+	switch kind {
+	case "LoadBalancer":
+		in := &lbcfv1.LoadBalancer{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return nil, err
+		}
+		converted := &lbcfapi.LoadBalancer{}
+		lbcfv1.Convert_v1_LoadBalancer_To_v1beta1_LoadBalancer(in, converted)
+		out = converted
+	case "BackendGroup":
+		in := &lbcfv1.BackendGroup{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return nil, err
+		}
+		converted := &lbcfapi.BackendGroup{}
+		lbcfv1.Convert_v1_BackendGroup_To_v1beta1_BackendGroup(in, converted)
+		out = converted
+	case "LoadBalancerDriver":
+		in := &lbcfv1.LoadBalancerDriver{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return nil, err
+		}
+		converted := &lbcfapi.LoadBalancerDriver{}
+		lbcfv1.Convert_v1_LoadBalancerDriver_To_v1beta1_LoadBalancerDriver(in, converted)
+		out = converted
+	case "BackendRecord":
+		in := &lbcfv1.BackendRecord{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return nil, err
+		}
+		converted := &lbcfapi.BackendRecord{}
+		lbcfv1.Convert_v1_BackendRecord_To_v1beta1_BackendRecord(in, converted)
+		out = converted
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", kind)
+	}
+	return json.Marshal(out)
+}
+
+func responseConversion(review *ConversionReview, rsp *restful.Response) {
+	if err := rsp.WriteAsJson(review); err != nil {
+		klog.Errorf("send conversion response failed: %v, review: %+v", err, *review)
+	}
+}
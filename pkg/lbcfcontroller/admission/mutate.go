@@ -18,6 +18,7 @@
 package admission
 
 import (
+	"fmt"
 	"path"
 	"strings"
 	"time"
@@ -94,6 +95,28 @@ func defaultPodProtocol() Patch {
 	}
 }
 
+func defaultNodeProtocol() Patch {
+	return Patch{
+		OP:    patchOpAdd,
+		Path:  "/spec/nodes/port/protocol",
+		Value: "TCP",
+	}
+}
+
+func defaultPortListProtocol(prefix string, ports []lbcfapi.PortSelector) []Patch {
+	var patches []Patch
+	for i, port := range ports {
+		if port.Protocol == "" {
+			patches = append(patches, Patch{
+				OP:    patchOpAdd,
+				Path:  fmt.Sprintf("%s/%d/protocol", prefix, i),
+				Value: "TCP",
+			})
+		}
+	}
+	return patches
+}
+
 type backendGroupPatch struct {
 	obj     *lbcfapi.BackendGroup
 	patches []Patch
@@ -115,10 +138,16 @@ func (bp *backendGroupPatch) addLabel() {
 }
 
 func (bp *backendGroupPatch) setDefaultProtocol() {
-	if bp.obj.Spec.Service != nil && bp.obj.Spec.Service.Port.Protocol == "" {
+	if bp.obj.Spec.Service != nil && len(bp.obj.Spec.Service.Ports) > 0 {
+		bp.patches = append(bp.patches, defaultPortListProtocol("/spec/service/ports", bp.obj.Spec.Service.Ports)...)
+	} else if bp.obj.Spec.Pods != nil && len(bp.obj.Spec.Pods.Ports) > 0 {
+		bp.patches = append(bp.patches, defaultPortListProtocol("/spec/pods/ports", bp.obj.Spec.Pods.Ports)...)
+	} else if bp.obj.Spec.Service != nil && bp.obj.Spec.Service.Port.Protocol == "" {
 		bp.patches = append(bp.patches, defaultSvcProtocol())
 	} else if bp.obj.Spec.Pods != nil && bp.obj.Spec.Pods.Port.Protocol == "" {
 		bp.patches = append(bp.patches, defaultPodProtocol())
+	} else if bp.obj.Spec.Nodes != nil && bp.obj.Spec.Nodes.Port.Protocol == "" {
+		bp.patches = append(bp.patches, defaultNodeProtocol())
 	}
 }
 
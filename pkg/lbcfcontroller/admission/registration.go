@@ -0,0 +1,284 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"tkestack.io/lb-controlling-framework/cmd/lbcf-controller/app/config"
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+
+	"github.com/emicklei/go-restful"
+	admissionregv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+const (
+	admissionregistrationGroup = "admissionregistration.k8s.io"
+
+	validatingWebhookConfigKind = "ValidatingWebhookConfiguration"
+	mutatingWebhookConfigKind   = "MutatingWebhookConfiguration"
+)
+
+// webhookConfigVersion returns the version of admissionregistration.k8s.io used for webhook configurations,
+// v1 is preferred, and v1beta1 is used if the apiserver does not serve v1
+func webhookConfigVersion(client discovery.DiscoveryInterface) (string, error) {
+	return serverGroupVersion(client, admissionregistrationGroup, "v1", "v1beta1")
+}
+
+func webhookConfigPath(version string, mutating bool) string {
+	resource := "validatingwebhookconfigurations"
+	if mutating {
+		resource = "mutatingwebhookconfigurations"
+	}
+	return fmt.Sprintf("/apis/%s/%s/%s", admissionregistrationGroup, version, resource)
+}
+
+// admitRoute is a webhook served by admit webhook server.
+// Routes are the only source of webhook registrations, a removed route is unregistered at the next startup.
+type admitRoute struct {
+	path       string
+	handler    restful.RouteFunction
+	mutating   bool
+	name       string
+	resource   string
+	operations []admissionregv1beta1.OperationType
+}
+
+func (s *Server) admitRoutes() []admitRoute {
+	create := admissionregv1beta1.Create
+	update := admissionregv1beta1.Update
+	del := admissionregv1beta1.Delete
+	return []admitRoute{
+		{
+			path:       "mutate-load-balancer",
+			handler:    s.MutateAdmitLoadBalancer,
+			mutating:   true,
+			name:       "lb.lbcf.tke.cloud.tencent.com",
+			resource:   "loadbalancers",
+			operations: []admissionregv1beta1.OperationType{create},
+		},
+		{
+			path:       "mutate-load-balancer-driver",
+			handler:    s.MutateAdmitLoadBalancerDriver,
+			mutating:   true,
+			name:       "driver.lbcf.tke.cloud.tencent.com",
+			resource:   "loadbalancerdrivers",
+			operations: []admissionregv1beta1.OperationType{create},
+		},
+		{
+			path:       "mutate-backend-broup",
+			handler:    s.MutateAdmitBackendGroup,
+			mutating:   true,
+			name:       "backendgroup.lbcf.tke.cloud.tencent.com",
+			resource:   "backendgroups",
+			operations: []admissionregv1beta1.OperationType{create, update},
+		},
+		{
+			path:       "validate-load-balancer",
+			handler:    s.ValidateAdmitLoadBalancer,
+			name:       "lb.lbcf.tke.cloud.tencent.com",
+			resource:   "loadbalancers",
+			operations: []admissionregv1beta1.OperationType{create, update},
+		},
+		{
+			path:       "validate-load-balancer-driver",
+			handler:    s.ValidateAdmitLoadBalancerDriver,
+			name:       "driver.lbcf.tke.cloud.tencent.com",
+			resource:   "loadbalancerdrivers",
+			operations: []admissionregv1beta1.OperationType{create, update, del},
+		},
+		{
+			path:       "validate-backend-group",
+			handler:    s.ValidateAdmitBackendGroup,
+			name:       "backendgroup.lbcf.tke.cloud.tencent.com",
+			resource:   "backendgroups",
+			operations: []admissionregv1beta1.OperationType{create, update},
+		},
+	}
+}
+
+// webhookConfiguration is the json form of Validating/MutatingWebhookConfiguration.
+// The vendored admissionregistration types lack timeoutSeconds, matchPolicy and admissionReviewVersions,
+// so configurations are written as raw json to keep these fields.
+type webhookConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Webhooks          []webhook `json:"webhooks"`
+}
+
+type webhook struct {
+	Name                    string                                   `json:"name"`
+	ClientConfig            admissionregv1beta1.WebhookClientConfig  `json:"clientConfig"`
+	Rules                   []admissionregv1beta1.RuleWithOperations `json:"rules"`
+	FailurePolicy           *admissionregv1beta1.FailurePolicyType   `json:"failurePolicy,omitempty"`
+	MatchPolicy             string                                   `json:"matchPolicy,omitempty"`
+	NamespaceSelector       *metav1.LabelSelector                    `json:"namespaceSelector,omitempty"`
+	SideEffects             *admissionregv1beta1.SideEffectClass     `json:"sideEffects,omitempty"`
+	TimeoutSeconds          *int32                                   `json:"timeoutSeconds,omitempty"`
+	AdmissionReviewVersions []string                                 `json:"admissionReviewVersions,omitempty"`
+}
+
+func newWebhookRegistrar(client kubernetes.Interface, cfg *config.Config, routes []admitRoute) (*webhookRegistrar, error) {
+	failurePolicy := admissionregv1beta1.FailurePolicyType(cfg.WebhookFailurePolicy)
+	if failurePolicy != admissionregv1beta1.Fail && failurePolicy != admissionregv1beta1.Ignore {
+		return nil, fmt.Errorf("invalid webhook failure policy %q, must be %s or %s",
+			cfg.WebhookFailurePolicy, admissionregv1beta1.Fail, admissionregv1beta1.Ignore)
+	}
+	if cfg.WebhookTimeoutSeconds < 1 || cfg.WebhookTimeoutSeconds > 30 {
+		return nil, fmt.Errorf("invalid webhook timeout %d, must be between 1 and 30", cfg.WebhookTimeoutSeconds)
+	}
+	namespaceSelector, err := metav1.ParseToLabelSelector(cfg.WebhookNamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook namespace selector %q: %v", cfg.WebhookNamespaceSelector, err)
+	}
+	return &webhookRegistrar{
+		client:            client,
+		cfg:               cfg,
+		routes:            routes,
+		failurePolicy:     failurePolicy,
+		timeoutSeconds:    int32(cfg.WebhookTimeoutSeconds),
+		namespaceSelector: namespaceSelector,
+	}, nil
+}
+
+// webhookRegistrar creates or updates the Validating/MutatingWebhookConfigurations of lbcf-controller
+type webhookRegistrar struct {
+	client kubernetes.Interface
+	cfg    *config.Config
+	routes []admitRoute
+
+	failurePolicy     admissionregv1beta1.FailurePolicyType
+	timeoutSeconds    int32
+	namespaceSelector *metav1.LabelSelector
+}
+
+// register registers all routes, if caBundle is nil, the caBundle in the existing configuration is kept
+func (r *webhookRegistrar) register(caBundle []byte) error {
+	version, err := webhookConfigVersion(r.client.Discovery())
+	if err != nil {
+		return err
+	}
+	if err := r.apply(version, validatingWebhookConfigKind,
+		r.cfg.ValidatingWebhookConfigName, false, caBundle); err != nil {
+		return err
+	}
+	return r.apply(version, mutatingWebhookConfigKind,
+		r.cfg.MutatingWebhookConfigName, true, caBundle)
+}
+
+func (r *webhookRegistrar) apply(version string, kind string, name string, mutating bool, caBundle []byte) error {
+	resourcePath := webhookConfigPath(version, mutating)
+	restClient := r.client.AdmissionregistrationV1beta1().RESTClient()
+	raw, err := restClient.Get().AbsPath(resourcePath, name).DoRaw()
+	if errors.IsNotFound(err) {
+		if caBundle == nil {
+			klog.Warningf("no caBundle is provided, %s %s is created without caBundle", kind, name)
+		}
+		body, err := json.Marshal(&webhookConfiguration{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: admissionregistrationGroup + "/" + version,
+				Kind:       kind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Webhooks: r.webhooks(mutating, caBundle),
+		})
+		if err != nil {
+			return err
+		}
+		if err := restClient.Post().AbsPath(resourcePath).Body(body).Do().Error(); err != nil {
+			return err
+		}
+		klog.Infof("%s %s created", kind, name)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if caBundle == nil {
+		existing := &webhookConfiguration{}
+		if err := json.Unmarshal(raw, existing); err != nil {
+			return err
+		}
+		for _, wh := range existing.Webhooks {
+			if len(wh.ClientConfig.CABundle) > 0 {
+				caBundle = wh.ClientConfig.CABundle
+				break
+			}
+		}
+	}
+	// a json merge patch replaces the whole list, so webhooks not in routes are removed
+	patch, err := json.Marshal(map[string]interface{}{
+		"webhooks": r.webhooks(mutating, caBundle),
+	})
+	if err != nil {
+		return err
+	}
+	if err := restClient.Patch(types.MergePatchType).AbsPath(resourcePath, name).Body(patch).Do().Error(); err != nil {
+		return err
+	}
+	klog.Infof("%s %s updated", kind, name)
+	return nil
+}
+
+func (r *webhookRegistrar) webhooks(mutating bool, caBundle []byte) []webhook {
+	sideEffects := admissionregv1beta1.SideEffectClassNone
+	webhooks := make([]webhook, 0)
+	for _, route := range r.routes {
+		if route.mutating != mutating {
+			continue
+		}
+		path := "/" + route.path
+		webhooks = append(webhooks, webhook{
+			Name: route.name,
+			ClientConfig: admissionregv1beta1.WebhookClientConfig{
+				Service: &admissionregv1beta1.ServiceReference{
+					Namespace: r.cfg.WebhookServiceNamespace,
+					Name:      r.cfg.WebhookServiceName,
+					Path:      &path,
+				},
+				CABundle: caBundle,
+			},
+			Rules: []admissionregv1beta1.RuleWithOperations{
+				{
+					Operations: route.operations,
+					Rule: admissionregv1beta1.Rule{
+						APIGroups:   []string{lbcfapi.SchemeGroupVersion.Group},
+						APIVersions: []string{lbcfapi.SchemeGroupVersion.Version},
+						Resources:   []string{route.resource},
+					},
+				},
+			},
+			FailurePolicy:           &r.failurePolicy,
+			MatchPolicy:             "Equivalent",
+			NamespaceSelector:       r.namespaceSelector,
+			SideEffects:             &sideEffects,
+			TimeoutSeconds:          &r.timeoutSeconds,
+			AdmissionReviewVersions: []string{"v1", "v1beta1"},
+		})
+	}
+	return webhooks
+}
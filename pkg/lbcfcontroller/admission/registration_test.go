@@ -0,0 +1,131 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package admission
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"testing"
+
+	"tkestack.io/lb-controlling-framework/cmd/lbcf-controller/app/config"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWebhookConfigVersion(t *testing.T) {
+	cases := []struct {
+		name             string
+		groupVersions    []string
+		expectValidating string
+		expectMutating   string
+	}{
+		{
+			name:             "v1-and-v1beta1",
+			groupVersions:    []string{"admissionregistration.k8s.io/v1beta1", "admissionregistration.k8s.io/v1"},
+			expectValidating: "/apis/admissionregistration.k8s.io/v1/validatingwebhookconfigurations",
+			expectMutating:   "/apis/admissionregistration.k8s.io/v1/mutatingwebhookconfigurations",
+		},
+		{
+			name:             "v1beta1-only",
+			groupVersions:    []string{"admissionregistration.k8s.io/v1beta1"},
+			expectValidating: "/apis/admissionregistration.k8s.io/v1beta1/validatingwebhookconfigurations",
+			expectMutating:   "/apis/admissionregistration.k8s.io/v1beta1/mutatingwebhookconfigurations",
+		},
+	}
+	for _, c := range cases {
+		client := fake.NewSimpleClientset()
+		for _, gv := range c.groupVersions {
+			client.Resources = append(client.Resources, &metav1.APIResourceList{GroupVersion: gv})
+		}
+		version, err := webhookConfigVersion(client.Discovery())
+		if err != nil {
+			t.Fatalf("case %s, unexpected error: %v", c.name, err)
+		}
+		if get := webhookConfigPath(version, false); get != c.expectValidating {
+			t.Errorf("case %s, expect %s, get %s", c.name, c.expectValidating, get)
+		}
+		if get := webhookConfigPath(version, true); get != c.expectMutating {
+			t.Errorf("case %s, expect %s, get %s", c.name, c.expectMutating, get)
+		}
+	}
+}
+
+// TestStaticWebhookConfigs makes sure deployments/admit.yaml, used with --register-webhooks=false,
+// registers the same webhooks as lbcf-controller does with default flags
+func TestStaticWebhookConfigs(t *testing.T) {
+	cfg := config.NewConfig()
+	fs := flag.NewFlagSet("lbcf-controller", flag.ContinueOnError)
+	cfg.AddFlags(fs)
+	if err := fs.Parse(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	registrar, err := newWebhookRegistrar(fake.NewSimpleClientset(), cfg, (&Server{}).admitRoutes())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f, err := os.Open("../../../deployments/admit.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+	static := map[string]*webhookConfiguration{}
+	decoder := yaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		wc := &webhookConfiguration{}
+		if err := decoder.Decode(wc); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		static[wc.Kind] = wc
+	}
+
+	cases := []struct {
+		kind     string
+		name     string
+		mutating bool
+	}{
+		{validatingWebhookConfigKind, cfg.ValidatingWebhookConfigName, false},
+		{mutatingWebhookConfigKind, cfg.MutatingWebhookConfigName, true},
+	}
+	for _, c := range cases {
+		wc := static[c.kind]
+		if wc == nil {
+			t.Fatalf("%s not found in admit.yaml", c.kind)
+		}
+		if wc.APIVersion != admissionregistrationGroup+"/v1" {
+			t.Errorf("%s, expect apiVersion %s/v1, get %s", c.kind, admissionregistrationGroup, wc.APIVersion)
+		}
+		if wc.Name != c.name {
+			t.Errorf("%s, expect name %s, get %s", c.kind, c.name, wc.Name)
+		}
+		if len(wc.Webhooks) == 0 {
+			t.Fatalf("%s has no webhooks", c.kind)
+		}
+		expect, _ := json.Marshal(registrar.webhooks(c.mutating, wc.Webhooks[0].ClientConfig.CABundle))
+		get, _ := json.Marshal(wc.Webhooks)
+		if string(expect) != string(get) {
+			t.Errorf("%s, expect webhooks\n%s\nget\n%s", c.kind, expect, get)
+		}
+	}
+}
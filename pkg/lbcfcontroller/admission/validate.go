@@ -26,12 +26,15 @@ import (
 	"strings"
 	"time"
 
+	lbcfv1 "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1"
 	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
 	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
 	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
 
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -46,7 +49,17 @@ func ValidateLoadBalancerDriver(raw *lbcfapi.LoadBalancerDriver) field.ErrorList
 	allErrs = append(allErrs,
 		validateDriverURL(raw.Spec.Url, field.NewPath("spec").Child("url"))...)
 	allErrs = append(allErrs,
-		validateDriverWebhooks(raw.Spec.Webhooks, field.NewPath("spec").Child("webhooks"))...)
+		validateProtocolVersion(raw.Spec.ProtocolVersion, field.NewPath("spec").Child("protocolVersion"))...)
+	allErrs = append(allErrs,
+		validateDriverWebhooks(raw.Spec.Webhooks, util.GetProtocolVersion(raw), field.NewPath("spec").Child("webhooks"))...)
+	if raw.Spec.ClientConfig != nil {
+		allErrs = append(allErrs,
+			validateDriverClientConfig(raw, field.NewPath("spec").Child("clientConfig"))...)
+	}
+	if raw.Spec.HealthCheck != nil {
+		allErrs = append(allErrs,
+			validateDriverHealthCheck(raw.Spec.HealthCheck, field.NewPath("spec").Child("healthCheck"))...)
+	}
 	return allErrs
 }
 
@@ -61,16 +74,45 @@ func ValidateLoadBalancer(raw *lbcfapi.LoadBalancer) field.ErrorList {
 		allErrs = append(allErrs,
 			validateEnsurePolicy(*raw.Spec.EnsurePolicy, field.NewPath("spec").Child("ensurePolicy"))...)
 	}
+	switch raw.Spec.DeletionPolicy {
+	case "", lbcfapi.DeletionPolicyDelete, lbcfapi.DeletionPolicyRetain:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("spec").Child("deletionPolicy"),
+			raw.Spec.DeletionPolicy, []string{
+				string(lbcfapi.DeletionPolicyDelete),
+				string(lbcfapi.DeletionPolicyRetain),
+			}))
+	}
+	for i, ns := range raw.Spec.AllowedNamespaces {
+		if ns == lbcfapi.AllNamespaces {
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Label(ns) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("allowedNamespaces").Index(i), ns, msg))
+		}
+	}
 	return allErrs
 }
 
 // ValidateBackendGroup validates BackendGroup
 func ValidateBackendGroup(raw *lbcfapi.BackendGroup) field.ErrorList {
 	allErrs := field.ErrorList{}
+	if _, ok := raw.Annotations[lbcfv1.AnnotationV1beta1SinglePort]; ok {
+		allErrs = append(allErrs, field.Forbidden(
+			field.NewPath("metadata").Child("annotations").Key(lbcfv1.AnnotationV1beta1SinglePort),
+			"annotation is reserved for conversion between v1 and v1beta1"))
+	}
 	if raw.Spec.EnsurePolicy != nil {
 		allErrs = append(allErrs,
 			validateEnsurePolicy(*raw.Spec.EnsurePolicy, field.NewPath("spec").Child("ensurePolicy"))...)
 	}
+	if raw.Spec.LBNamespace != "" {
+		for _, msg := range validation.IsDNS1123Label(raw.Spec.LBNamespace) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("lbNamespace"), raw.Spec.LBNamespace, msg))
+		}
+	}
 	allErrs = append(allErrs, validateBackends(&raw.Spec, field.NewPath("spec"))...)
 	return allErrs
 }
@@ -94,6 +136,14 @@ func LBUpdatedFieldsAllowed(cur *lbcfapi.LoadBalancer, old *lbcfapi.LoadBalancer
 	if !reflect.DeepEqual(cur.Spec.LBSpec, old.Spec.LBSpec) {
 		return false, "updating lbSpec is prohibited"
 	}
+	if cur.Spec.Import != old.Spec.Import {
+		return false, "updating import is prohibited"
+	}
+	if util.LBCreated(old) && util.GetDeletionPolicy(cur) != util.GetDeletionPolicy(old) &&
+		strings.ToLower(cur.Annotations[lbcfapi.AnnotationForceDeletionPolicy]) != "true" {
+		return false, fmt.Sprintf("updating deletionPolicy of a created LoadBalancer is prohibited, "+
+			"set annotation %s to \"true\" to force the update", lbcfapi.AnnotationForceDeletionPolicy)
+	}
 	return true, ""
 }
 
@@ -102,6 +152,9 @@ func BackendGroupUpdateFieldsAllowed(cur *lbcfapi.BackendGroup, old *lbcfapi.Bac
 	if cur.Spec.LBName != old.Spec.LBName {
 		return false, "updating lbName is prohibited"
 	}
+	if util.GetLBNamespace(cur) != util.GetLBNamespace(old) {
+		return false, "updating lbNamespace is prohibited"
+	}
 	if util.GetBackendType(cur) != util.GetBackendType(old) {
 		return false, "changing backend type is prohibited"
 	}
@@ -148,9 +201,9 @@ func validateDriverName(name string, namespace string, path *field.Path) field.E
 
 func validateDriverType(raw string, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	if raw != string(lbcfapi.WebhookDriver) {
-		allErrs = append(allErrs, field.Invalid(path, raw,
-			fmt.Sprintf("driverType must be %v", lbcfapi.WebhookDriver)))
+	if raw != string(lbcfapi.WebhookDriver) && raw != string(lbcfapi.GRPCDriver) {
+		allErrs = append(allErrs, field.NotSupported(path, raw,
+			[]string{string(lbcfapi.WebhookDriver), string(lbcfapi.GRPCDriver)}))
 	}
 	return allErrs
 }
@@ -163,27 +216,107 @@ func validateDriverURL(raw string, path *field.Path) field.ErrorList {
 	return allErrs
 }
 
-func validateDriverWebhooks(raw []lbcfapi.WebhookConfig, path *field.Path) field.ErrorList {
+func validateDriverHealthCheck(raw *lbcfapi.DriverHealthCheck, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	period := util.GetDuration(raw.Period, util.DefaultHealthCheckPeriod)
+	timeout := util.GetDuration(raw.Timeout, util.DefaultHealthCheckTimeout)
+	if period < 5*time.Second {
+		allErrs = append(allErrs, field.Invalid(path.Child("period"), period.String(),
+			"period must be greater than or equal to 5s"))
+	}
+	if timeout <= 0 || timeout > period {
+		allErrs = append(allErrs, field.Invalid(path.Child("timeout"), timeout.String(),
+			"timeout must be greater than 0 and less than or equal to period"))
+	}
+	return allErrs
+}
+
+func validateDriverClientConfig(driver *lbcfapi.LoadBalancerDriver, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	raw := driver.Spec.ClientConfig
+	secrets := []struct {
+		name  string
+		field string
+	}{
+		{raw.CASecret, "caSecret"},
+		{raw.ClientCertSecret, "clientCertSecret"},
+		{raw.TokenSecret, "tokenSecret"},
+	}
+	for _, secret := range secrets {
+		if secret.name == "" {
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(secret.name) {
+			allErrs = append(allErrs, field.Invalid(path.Child(secret.field), secret.name, msg))
+		}
+	}
+
+	// the bearer token must never be sent in cleartext
+	if raw.TokenSecret != "" {
+		if driver.Spec.DriverType == string(lbcfapi.GRPCDriver) {
+			if raw.CASecret == "" && raw.ClientCertSecret == "" {
+				allErrs = append(allErrs, field.Forbidden(path.Child("tokenSecret"),
+					"tokenSecret requires caSecret or clientCertSecret for GRPC drivers"))
+			}
+		} else if u, err := url.Parse(driver.Spec.Url); err == nil && u.Scheme != "https" {
+			allErrs = append(allErrs, field.Forbidden(path.Child("tokenSecret"),
+				"tokenSecret requires spec.url to be https"))
+		}
+	}
+	return allErrs
+}
+
+func validateWeightConfig(raw *lbcfapi.WeightConfig, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if raw.Annotation != "" {
+		for _, msg := range validation.IsQualifiedName(raw.Annotation) {
+			allErrs = append(allErrs, field.Invalid(path.Child("annotation"), raw.Annotation, msg))
+		}
+	}
+	if raw.DefaultWeight != nil && *raw.DefaultWeight < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("defaultWeight"), *raw.DefaultWeight,
+			"defaultWeight must not be negative"))
+	}
+	return allErrs
+}
+
+func validateProtocolVersion(raw lbcfapi.ProtocolVersion, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	var supported []string
-	for known := range webhooks.KnownWebhooks {
-		supported = append(supported, known)
+	switch raw {
+	case "", lbcfapi.ProtocolVersionV1, lbcfapi.ProtocolVersionV2:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path, raw, []string{
+			string(lbcfapi.ProtocolVersionV1),
+			string(lbcfapi.ProtocolVersionV2),
+		}))
+	}
+	return allErrs
+}
+
+func validateDriverWebhooks(raw []lbcfapi.WebhookConfig, version lbcfapi.ProtocolVersion, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	supported := webhooks.KnownWebhooks.Union(webhooks.OptionalWebhooks)
+	required := webhooks.KnownWebhooks
+	if version == lbcfapi.ProtocolVersionV2 {
+		required = webhooks.CoreWebhooks
 	}
 
 	hasWebhook := make(map[string]lbcfapi.WebhookConfig)
 	for _, wh := range raw {
 		hasWebhook[wh.Name] = wh
-		if _, ok := webhooks.KnownWebhooks[wh.Name]; !ok {
-			allErrs = append(allErrs, field.NotSupported(path.Child(wh.Name).Child("name"), wh.Name, supported))
+		if !supported.Has(wh.Name) {
+			allErrs = append(allErrs, field.NotSupported(path.Child(wh.Name).Child("name"), wh.Name, supported.List()))
 		}
 	}
 	if len(allErrs) > 0 {
 		return allErrs
 	}
 
-	for known := range webhooks.KnownWebhooks {
+	for known := range supported {
 		wh, ok := hasWebhook[known]
-		if !ok {
+		if !ok && !required.Has(known) {
+			continue
+		} else if !ok {
 			allErrs = append(allErrs, field.Required(path.Child(known),
 				fmt.Sprintf("webhook %s must be configured", known)))
 			continue
@@ -202,56 +335,90 @@ func validateDriverWebhooks(raw []lbcfapi.WebhookConfig, path *field.Path) field
 func validateBackends(raw *lbcfapi.BackendGroupSpec, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	var specified []string
 	if raw.Service != nil {
-		if raw.Pods != nil {
-			allErrs = append(allErrs,
-				field.Invalid(path.Child("pods"), raw.Pods,
-					"only one of \"service, pods, static\" is allowed"))
-		} else if raw.Static != nil {
-			allErrs = append(allErrs,
-				field.Invalid(path.Child("static"), raw.Pods,
-					"only one of \"service, pods, static\" is allowed"))
-		} else {
-			allErrs = append(allErrs, validateServiceBackend(raw.Service, path.Child("service"))...)
-		}
-		return allErrs
+		specified = append(specified, "service")
 	}
-
 	if raw.Pods != nil {
-		if raw.Static != nil {
-			allErrs = append(allErrs,
-				field.Invalid(path.Child("static"), raw.Pods,
-					"only one of \"service, pods, static\" is allowed"))
-		} else {
-			allErrs = append(allErrs, validatePodBackend(raw.Pods, path.Child("pods"))...)
-		}
+		specified = append(specified, "pods")
+	}
+	if raw.Static != nil {
+		specified = append(specified, "static")
+	}
+	if raw.Nodes != nil {
+		specified = append(specified, "nodes")
+	}
+	if len(specified) > 1 {
+		allErrs = append(allErrs,
+			field.Invalid(path.Child(specified[1]), strings.Join(specified, ", "),
+				"only one of \"service, pods, static, nodes\" is allowed"))
 		return allErrs
 	}
+
+	if raw.Service != nil {
+		allErrs = append(allErrs, validateServiceBackend(raw.Service, path.Child("service"))...)
+	} else if raw.Pods != nil {
+		allErrs = append(allErrs, validatePodBackend(raw.Pods, path.Child("pods"))...)
+	} else if raw.Nodes != nil {
+		allErrs = append(allErrs, validateNodeBackend(raw.Nodes, path.Child("nodes"))...)
+	}
 	return allErrs
 }
 
 func validateServiceBackend(raw *lbcfapi.ServiceBackend, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validatePorts(raw.Port, raw.Ports, path)...)
+	allErrs = append(allErrs, validateLabelSelector(raw.NodeSelector, raw.NodeMatchExpressions,
+		path.Child("nodeSelector"), path.Child("nodeMatchExpressions"))...)
+	switch raw.Mode {
+	case "", lbcfapi.ServiceModeNodePort, lbcfapi.ServiceModeEndpointPod, lbcfapi.ServiceModeEndpointNode:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("mode"), raw.Mode, []string{
+			string(lbcfapi.ServiceModeNodePort),
+			string(lbcfapi.ServiceModeEndpointPod),
+			string(lbcfapi.ServiceModeEndpointNode),
+		}))
+	}
+	return allErrs
+}
+
+func validateNodeBackend(raw *lbcfapi.NodeBackend, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validatePortSelector(raw.Port, path.Child("port"))...)
-	allErrs = append(allErrs, validateLabelSelector(raw.NodeSelector, path.Child("nodeSelector"))...)
+	if raw.Port.PortName != "" {
+		allErrs = append(allErrs,
+			field.Forbidden(path.Child("port").Child("portName"), "portName is not supported by node backends"))
+	}
+	allErrs = append(allErrs, validateLabelSelector(raw.Selector, raw.MatchExpressions,
+		path.Child("selector"), path.Child("matchExpressions"))...)
 	return allErrs
 }
 
 func validatePodBackend(raw *lbcfapi.PodBackend, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	allErrs = append(allErrs, validatePortSelector(raw.Port, path.Child("port"))...)
+	allErrs = append(allErrs, validatePorts(raw.Port, raw.Ports, path)...)
+	if raw.Drain != nil && raw.Drain.DrainPeriod.Duration < 0 {
+		allErrs = append(allErrs,
+			field.Invalid(path.Child("drain").Child("drainPeriod"), raw.Drain.DrainPeriod.String(),
+				"drainPeriod must not be negative"))
+	}
+	if raw.Weight != nil {
+		allErrs = append(allErrs, validateWeightConfig(raw.Weight, path.Child("weight"))...)
+	}
 	if raw.ByLabel != nil {
 		if raw.ByName != nil {
 			allErrs = append(allErrs,
 				field.Invalid(path.Child("byName"), raw.ByName,
 					"only one of \"byLabel, byName\" is allowed"))
 		}
-		if len(raw.ByLabel.Selector) == 0 {
+		if len(raw.ByLabel.Selector) == 0 && len(raw.ByLabel.MatchExpressions) == 0 {
 			allErrs = append(allErrs,
-				field.Required(path.Child("byLabel").Child("selector"), "selector must be specified"))
+				field.Required(path.Child("byLabel").Child("selector"),
+					"one of \"selector, matchExpressions\" must be specified"))
 		}
 		allErrs = append(allErrs,
-			validateLabelSelector(raw.ByLabel.Selector, path.Child("byLabel").Child("selector"))...)
+			validateLabelSelector(raw.ByLabel.Selector, raw.ByLabel.MatchExpressions,
+				path.Child("byLabel").Child("selector"), path.Child("byLabel").Child("matchExpressions"))...)
 		return allErrs
 	}
 
@@ -262,10 +429,40 @@ func validatePodBackend(raw *lbcfapi.PodBackend, path *field.Path) field.ErrorLi
 	return allErrs
 }
 
+// validatePorts validates port and ports, only one of them is allowed
+func validatePorts(port lbcfapi.PortSelector, ports []lbcfapi.PortSelector, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(ports) == 0 {
+		return validatePortSelector(port, path.Child("port"))
+	}
+	if port != (lbcfapi.PortSelector{}) {
+		allErrs = append(allErrs,
+			field.Invalid(path.Child("port"), port, "only one of \"port, ports\" is allowed"))
+	}
+	seen := make(map[lbcfapi.PortSelector]bool)
+	for i, p := range ports {
+		allErrs = append(allErrs, validatePortSelector(p, path.Child("ports").Index(i))...)
+		if seen[p] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("ports").Index(i), p))
+		}
+		seen[p] = true
+	}
+	return allErrs
+}
+
 func validatePortSelector(raw lbcfapi.PortSelector, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if raw.PortNumber <= 0 || raw.PortNumber > 65535 {
+	if raw.PortName != "" {
+		if raw.PortNumber != 0 {
+			allErrs = append(allErrs,
+				field.Invalid(path.Child("portName"), raw.PortName,
+					"only one of \"portNumber, portName\" is allowed"))
+		}
+		for _, msg := range validation.IsValidPortName(raw.PortName) {
+			allErrs = append(allErrs, field.Invalid(path.Child("portName"), raw.PortName, msg))
+		}
+	} else if raw.PortNumber <= 0 || raw.PortNumber > 65535 {
 		allErrs = append(allErrs,
 			field.Invalid(path.Child("portNumber"), raw.PortNumber,
 				"portNumber must be greater than 0 and less than 65536"))
@@ -278,7 +475,8 @@ func validatePortSelector(raw lbcfapi.PortSelector, path *field.Path) field.Erro
 	return allErrs
 }
 
-func validateLabelSelector(raw map[string]string, path *field.Path) field.ErrorList {
+func validateLabelSelector(raw map[string]string, exprs []metav1.LabelSelectorRequirement,
+	path *field.Path, exprPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for k, v := range raw {
 		_, err := labels.NewRequirement(k, selection.Equals, []string{v})
@@ -287,5 +485,8 @@ func validateLabelSelector(raw map[string]string, path *field.Path) field.ErrorL
 				field.Invalid(path, fmt.Sprintf("%v:%v", k, v), fmt.Sprintf("invalid label: %v", err)))
 		}
 	}
+	for i, expr := range exprs {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelectorRequirement(expr, exprPath.Index(i))...)
+	}
 	return allErrs
 }
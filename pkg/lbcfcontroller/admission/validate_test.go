@@ -0,0 +1,242 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package admission
+
+import (
+	"testing"
+	"time"
+
+	lbcfv1 "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1"
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func newTestWebhookConfigs(timeout time.Duration, names ...string) []lbcfapi.WebhookConfig {
+	var configs []lbcfapi.WebhookConfig
+	for _, name := range names {
+		configs = append(configs, lbcfapi.WebhookConfig{
+			Name:    name,
+			Timeout: lbcfapi.Duration{Duration: timeout},
+		})
+	}
+	return configs
+}
+
+func repeatErrorType(errType field.ErrorType, n int) []field.ErrorType {
+	var types []field.ErrorType
+	for i := 0; i < n; i++ {
+		types = append(types, errType)
+	}
+	return types
+}
+
+func TestProtocolV1WebhooksFrozen(t *testing.T) {
+	v1Webhooks := sets.NewString(
+		webhooks.ValidateLoadBalancer,
+		webhooks.CreateLoadBalancer,
+		webhooks.EnsureLoadBalancer,
+		webhooks.DeleteLoadBalancer,
+		webhooks.ValidateBackend,
+		webhooks.GenerateBackendAddr,
+		webhooks.EnsureBackend,
+		webhooks.DeregBackend,
+	)
+	if !webhooks.KnownWebhooks.Equal(v1Webhooks) {
+		t.Fatalf("webhooks required by protocol v1 must not change, got %v", webhooks.KnownWebhooks.List())
+	}
+	if !webhooks.KnownWebhooks.IsSuperset(webhooks.CoreWebhooks) {
+		t.Errorf("expect core webhooks to be required by protocol v1")
+	}
+	if webhooks.KnownWebhooks.HasAny(webhooks.OptionalWebhooks.List()...) {
+		t.Errorf("expect optional webhooks not to be required by protocol v1")
+	}
+}
+
+func TestValidateDriverWebhooks(t *testing.T) {
+	v1All := webhooks.KnownWebhooks.List()
+	v2Core := webhooks.CoreWebhooks.List()
+	cases := []struct {
+		name        string
+		version     lbcfapi.ProtocolVersion
+		configs     []lbcfapi.WebhookConfig
+		expectTypes []field.ErrorType
+	}{
+		{
+			name:    "v1-all",
+			version: lbcfapi.ProtocolVersionV1,
+			configs: newTestWebhookConfigs(10*time.Second, v1All...),
+		},
+		{
+			name:    "v1-opt-in",
+			version: lbcfapi.ProtocolVersionV1,
+			configs: newTestWebhookConfigs(10*time.Second, append(v1All, webhooks.OptionalWebhooks.List()...)...),
+		},
+		{
+			name:        "v1-missing",
+			version:     lbcfapi.ProtocolVersionV1,
+			configs:     newTestWebhookConfigs(10*time.Second, v1All[1:]...),
+			expectTypes: []field.ErrorType{field.ErrorTypeRequired},
+		},
+		{
+			name:        "v1-core-only",
+			version:     lbcfapi.ProtocolVersionV1,
+			configs:     newTestWebhookConfigs(10*time.Second, v2Core...),
+			expectTypes: []field.ErrorType{field.ErrorTypeRequired, field.ErrorTypeRequired},
+		},
+		{
+			name:        "v1-unknown",
+			version:     lbcfapi.ProtocolVersionV1,
+			configs:     newTestWebhookConfigs(10*time.Second, append(v1All, "healthCheck")...),
+			expectTypes: []field.ErrorType{field.ErrorTypeNotSupported},
+		},
+		{
+			name:    "v2-core",
+			version: lbcfapi.ProtocolVersionV2,
+			configs: newTestWebhookConfigs(10*time.Second, v2Core...),
+		},
+		{
+			name:    "v2-opt-in",
+			version: lbcfapi.ProtocolVersionV2,
+			configs: newTestWebhookConfigs(10*time.Second,
+				append(v2Core, webhooks.ValidateBackend, webhooks.ImportLoadBalancer, webhooks.BatchEnsureBackend)...),
+		},
+		{
+			name:        "v2-missing",
+			version:     lbcfapi.ProtocolVersionV2,
+			configs:     newTestWebhookConfigs(10*time.Second, webhooks.CreateLoadBalancer),
+			expectTypes: repeatErrorType(field.ErrorTypeRequired, 5),
+		},
+		{
+			name:    "v2-no-timeout",
+			version: lbcfapi.ProtocolVersionV2,
+			configs: append(newTestWebhookConfigs(10*time.Second, v2Core...),
+				newTestWebhookConfigs(0, webhooks.BatchDeregBackend)...),
+			expectTypes: []field.ErrorType{field.ErrorTypeInvalid},
+		},
+		{
+			name:        "v2-timeout-too-long",
+			version:     lbcfapi.ProtocolVersionV2,
+			configs:     newTestWebhookConfigs(2*time.Minute, v2Core...),
+			expectTypes: repeatErrorType(field.ErrorTypeInvalid, len(v2Core)),
+		},
+	}
+	for _, c := range cases {
+		errList := validateDriverWebhooks(c.configs, c.version, field.NewPath("spec").Child("webhooks"))
+		if len(errList) != len(c.expectTypes) {
+			t.Errorf("case %s: expect %d errors, got %v", c.name, len(c.expectTypes), errList)
+			continue
+		}
+		for i, err := range errList {
+			if err.Type != c.expectTypes[i] {
+				t.Errorf("case %s: expect error type %s, got %v", c.name, c.expectTypes[i], err)
+			}
+		}
+	}
+}
+
+func TestValidateDriverClientConfigToken(t *testing.T) {
+	cases := []struct {
+		name        string
+		driverType  lbcfapi.DriverType
+		url         string
+		config      lbcfapi.DriverClientConfig
+		expectTypes []field.ErrorType
+	}{
+		{
+			name:       "webhook-https",
+			driverType: lbcfapi.WebhookDriver,
+			url:        "https://driver.kube-system.svc",
+			config:     lbcfapi.DriverClientConfig{TokenSecret: "token"},
+		},
+		{
+			name:        "webhook-http",
+			driverType:  lbcfapi.WebhookDriver,
+			url:         "http://driver.kube-system.svc",
+			config:      lbcfapi.DriverClientConfig{TokenSecret: "token"},
+			expectTypes: []field.ErrorType{field.ErrorTypeForbidden},
+		},
+		{
+			name:       "webhook-http-no-token",
+			driverType: lbcfapi.WebhookDriver,
+			url:        "http://driver.kube-system.svc",
+			config:     lbcfapi.DriverClientConfig{CASecret: "ca"},
+		},
+		{
+			name:       "grpc-tls",
+			driverType: lbcfapi.GRPCDriver,
+			url:        "driver.kube-system.svc:443",
+			config:     lbcfapi.DriverClientConfig{CASecret: "ca", TokenSecret: "token"},
+		},
+		{
+			name:        "grpc-insecure",
+			driverType:  lbcfapi.GRPCDriver,
+			url:         "driver.kube-system.svc:443",
+			config:      lbcfapi.DriverClientConfig{TokenSecret: "token"},
+			expectTypes: []field.ErrorType{field.ErrorTypeForbidden},
+		},
+	}
+	for _, c := range cases {
+		driver := &lbcfapi.LoadBalancerDriver{
+			Spec: lbcfapi.LoadBalancerDriverSpec{
+				DriverType:   string(c.driverType),
+				Url:          c.url,
+				ClientConfig: &c.config,
+			},
+		}
+		errList := validateDriverClientConfig(driver, field.NewPath("spec").Child("clientConfig"))
+		if len(errList) != len(c.expectTypes) {
+			t.Errorf("case %s: expect %d errors, got %v", c.name, len(c.expectTypes), errList)
+			continue
+		}
+		for i, err := range errList {
+			if err.Type != c.expectTypes[i] {
+				t.Errorf("case %s: expect error type %s, got %v", c.name, c.expectTypes[i], err)
+			}
+		}
+	}
+}
+
+func TestValidateBackendGroupReservedAnnotation(t *testing.T) {
+	group := &lbcfapi.BackendGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "group",
+			Namespace:   "default",
+			Annotations: map[string]string{lbcfv1.AnnotationV1beta1SinglePort: "pods"},
+		},
+		Spec: lbcfapi.BackendGroupSpec{
+			LBName: "lb",
+			Pods: &lbcfapi.PodBackend{
+				Port:   lbcfapi.PortSelector{PortNumber: 80, Protocol: "TCP"},
+				ByName: []string{"pod-0"},
+			},
+		},
+	}
+	errList := ValidateBackendGroup(group)
+	if len(errList) != 1 || errList[0].Type != field.ErrorTypeForbidden {
+		t.Fatalf("expect 1 forbidden error, got %v", errList.ToAggregate())
+	}
+
+	delete(group.Annotations, lbcfv1.AnnotationV1beta1SinglePort)
+	if errList := ValidateBackendGroup(group); len(errList) != 0 {
+		t.Errorf("expect no error, got %v", errList.ToAggregate())
+	}
+}
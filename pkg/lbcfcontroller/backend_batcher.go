@@ -0,0 +1,261 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package lbcfcontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/klog"
+)
+
+const (
+	// maxBackendBatchSize is the maximum number of backends in a batch, a batch is sent immediately once it is full
+	maxBackendBatchSize = 100
+	// backendBatchResultTTL is how long the result of a sent batch is kept for BackendRecords that have not been synced
+	backendBatchResultTTL = 10 * time.Minute
+)
+
+func newBackendBatcher(invoker util.WebhookInvoker, window time.Duration) *backendBatcher {
+	return &backendBatcher{
+		invoker: invoker,
+		window:  window,
+		batches: make(map[string]*backendBatch),
+		pending: sets.NewString(),
+		results: make(map[string]*backendBatchResult),
+	}
+}
+
+// backendBatcher coalesces ensureBackend and deregisterBackend calls on the same load balancer into
+// batchEnsureBackend and batchDeregisterBackend calls.
+//
+// The first call on a load balancer starts a batch, calls on the same load balancer within window join the batch.
+// Callers are not blocked while the batch is waiting or being sent, so that a batch is not limited by the number
+// of workers. Once the batch is sent, the result of each backend is stored and the BackendRecord is passed to
+// onDone, the next call for the same request returns the stored result.
+type backendBatcher struct {
+	sync.Mutex
+	invoker util.WebhookInvoker
+	window  time.Duration
+	batches map[string]*backendBatch
+	// pending contains RecordIDs of requests in batches that are not responded
+	pending sets.String
+	// results contains results of sent batches by RecordID
+	results map[string]*backendBatchResult
+	// onDone is called with the key of BackendRecord when the result of its request is stored
+	onDone func(key string)
+}
+
+type backendBatch struct {
+	driver      *lbcfapi.LoadBalancerDriver
+	webhookName string
+	lbInfo      map[string]string
+	items       []webhooks.BatchBackendOperationItem
+	keys        []string
+	digests     []string
+	timer       *time.Timer
+}
+
+type backendBatchResult struct {
+	digest  string
+	rsp     *webhooks.BackendOperationResponse
+	err     error
+	expires time.Time
+}
+
+// setOnDone replaces the function called when the result of a request is stored
+func (b *backendBatcher) setOnDone(onDone func(key string)) {
+	b.Lock()
+	defer b.Unlock()
+	b.onDone = onDone
+}
+
+// call returns the result of req if it is sent in a batch, otherwise req is added to a batch and done is false.
+// key is the key of the BackendRecord that req belongs to, it is passed to onDone once the batch is sent.
+// webhookName must be either batchEnsureBackend or batchDeregisterBackend
+func (b *backendBatcher) call(key string, driver *lbcfapi.LoadBalancerDriver, webhookName string,
+	req *webhooks.BackendOperationRequest) (rsp *webhooks.BackendOperationResponse, done bool, err error) {
+	lbInfo, err := json.Marshal(req.LBInfo)
+	if err != nil {
+		return nil, true, fmt.Errorf("encode lbInfo failed: %v", err)
+	}
+	digest, err := backendRequestDigest(driver, webhookName, req)
+	if err != nil {
+		return nil, true, err
+	}
+	batchKey := fmt.Sprintf("%s/%s/%s/%s", webhookName, driver.Namespace, driver.Name, lbInfo)
+
+	b.Lock()
+	if result, ok := b.results[req.RecordID]; ok {
+		delete(b.results, req.RecordID)
+		// results of outdated requests, e.g., the weight is changed after the request is sent, are discarded
+		if result.digest == digest {
+			b.Unlock()
+			return result.rsp, true, result.err
+		}
+	}
+	if b.pending.Has(req.RecordID) {
+		b.Unlock()
+		return nil, false, nil
+	}
+	batch, ok := b.batches[batchKey]
+	if !ok {
+		batch = &backendBatch{
+			driver:      driver,
+			webhookName: webhookName,
+			lbInfo:      req.LBInfo,
+		}
+		b.batches[batchKey] = batch
+		batch.timer = time.AfterFunc(b.window, func() {
+			b.flush(batchKey, batch)
+		})
+	}
+	batch.items = append(batch.items, webhooks.BatchBackendOperationItem{
+		RecordID:     req.RecordID,
+		BackendAddr:  req.BackendAddr,
+		Parameters:   req.Parameters,
+		InjectedInfo: req.InjectedInfo,
+		Weight:       req.Weight,
+	})
+	batch.keys = append(batch.keys, key)
+	batch.digests = append(batch.digests, digest)
+	b.pending.Insert(req.RecordID)
+	full := len(batch.items) >= maxBackendBatchSize
+	if full {
+		// calls after this one start a new batch
+		delete(b.batches, batchKey)
+	}
+	b.Unlock()
+
+	if full && batch.timer.Stop() {
+		go b.flush(batchKey, batch)
+	}
+	return nil, false, nil
+}
+
+// flush sends batch, stores the result of each backend and notifies onDone
+func (b *backendBatcher) flush(batchKey string, batch *backendBatch) {
+	b.Lock()
+	if b.batches[batchKey] == batch {
+		delete(b.batches, batchKey)
+	}
+	b.Unlock()
+
+	req := &webhooks.BatchBackendOperationRequest{
+		RequestForRetryHooks: webhooks.RequestForRetryHooks{
+			RecordID: fmt.Sprintf("%s(%s)", batch.webhookName, uuid.NewUUID()),
+			RetryID:  string(uuid.NewUUID()),
+		},
+		LBInfo:   batch.lbInfo,
+		Backends: batch.items,
+	}
+	klog.V(3).Infof("%s on driver %s/%s with %d backends",
+		batch.webhookName, batch.driver.Namespace, batch.driver.Name, len(batch.items))
+	var rsp *webhooks.BatchBackendOperationResponse
+	var err error
+	if batch.webhookName == webhooks.BatchEnsureBackend {
+		rsp, err = b.invoker.CallBatchEnsureBackend(batch.driver, req)
+	} else {
+		rsp, err = b.invoker.CallBatchDeregisterBackend(batch.driver, req)
+	}
+
+	results := make(map[string]webhooks.BatchBackendOperationResult)
+	if err == nil {
+		for _, r := range rsp.Results {
+			results[r.RecordID] = r
+		}
+	}
+	expires := time.Now().Add(backendBatchResultTTL)
+	b.Lock()
+	b.evictExpired()
+	for i, item := range batch.items {
+		result := &backendBatchResult{
+			digest:  batch.digests[i],
+			err:     err,
+			expires: expires,
+		}
+		if err == nil {
+			result.rsp = splitBatchResponse(batch.webhookName, item, rsp, results)
+		}
+		b.results[item.RecordID] = result
+		b.pending.Delete(item.RecordID)
+	}
+	onDone := b.onDone
+	b.Unlock()
+
+	if onDone != nil {
+		for _, key := range batch.keys {
+			onDone(key)
+		}
+	}
+}
+
+// evictExpired removes results that are not taken, e.g., the BackendRecord is deleted
+func (b *backendBatcher) evictExpired() {
+	now := time.Now()
+	for id, result := range b.results {
+		if !now.Before(result.expires) {
+			delete(b.results, id)
+		}
+	}
+}
+
+func splitBatchResponse(webhookName string, item webhooks.BatchBackendOperationItem,
+	rsp *webhooks.BatchBackendOperationResponse,
+	results map[string]webhooks.BatchBackendOperationResult) *webhooks.BackendOperationResponse {
+	itemRsp := &webhooks.BackendOperationResponse{
+		ResponseForFailRetryHooks: rsp.ResponseForFailRetryHooks,
+	}
+	if rsp.Status == webhooks.StatusSucc {
+		if r, ok := results[item.RecordID]; ok {
+			itemRsp.ResponseForFailRetryHooks = r.ResponseForFailRetryHooks
+			itemRsp.InjectedInfo = r.InjectedInfo
+		} else {
+			itemRsp.Status = webhooks.StatusFail
+			itemRsp.Msg = fmt.Sprintf("no result for %s in %s response", item.RecordID, webhookName)
+		}
+	}
+	return itemRsp
+}
+
+// backendRequestDigest identifies the content of req, so that the result of an outdated request is not used
+func backendRequestDigest(driver *lbcfapi.LoadBalancerDriver, webhookName string,
+	req *webhooks.BackendOperationRequest) (string, error) {
+	raw, err := json.Marshal([]interface{}{
+		driver.Namespace,
+		driver.Name,
+		webhookName,
+		req.LBInfo,
+		req.BackendAddr,
+		req.Parameters,
+		req.InjectedInfo,
+		req.Weight,
+	})
+	if err != nil {
+		return "", fmt.Errorf("encode request failed: %v", err)
+	}
+	return string(raw), nil
+}
@@ -0,0 +1,181 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package lbcfcontroller
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeBatchInvoker struct {
+	util.WebhookInvoker
+	sync.Mutex
+	batchSizes []int
+	err        error
+}
+
+func (f *fakeBatchInvoker) CallBatchEnsureBackend(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.BatchBackendOperationRequest) (*webhooks.BatchBackendOperationResponse, error) {
+	f.Lock()
+	defer f.Unlock()
+	f.batchSizes = append(f.batchSizes, len(req.Backends))
+	if f.err != nil {
+		return nil, f.err
+	}
+	rsp := &webhooks.BatchBackendOperationResponse{
+		ResponseForFailRetryHooks: webhooks.ResponseForFailRetryHooks{Status: webhooks.StatusSucc},
+	}
+	for _, item := range req.Backends {
+		rsp.Results = append(rsp.Results, webhooks.BatchBackendOperationResult{
+			ResponseForFailRetryHooks: webhooks.ResponseForFailRetryHooks{Status: webhooks.StatusSucc},
+			RecordID:                  item.RecordID,
+			InjectedInfo:              map[string]string{"addr": item.BackendAddr},
+		})
+	}
+	return rsp, nil
+}
+
+func (f *fakeBatchInvoker) sizes() []int {
+	f.Lock()
+	defer f.Unlock()
+	return append([]int(nil), f.batchSizes...)
+}
+
+func newTestBatcher(invoker util.WebhookInvoker) (*backendBatcher, chan string) {
+	done := make(chan string, 1000)
+	b := newBackendBatcher(invoker, 50*time.Millisecond)
+	b.setOnDone(func(key string) { done <- key })
+	return b, done
+}
+
+func newTestBatchRequest(i int) (string, *webhooks.BackendOperationRequest) {
+	return fmt.Sprintf("default/record-%d", i), &webhooks.BackendOperationRequest{
+		RequestForRetryHooks: webhooks.RequestForRetryHooks{
+			RecordID: fmt.Sprintf("ensureBackend(uid-%d)", i),
+		},
+		LBInfo:      map[string]string{"lbID": "lb-1"},
+		BackendAddr: fmt.Sprintf("10.0.0.%d:80", i),
+	}
+}
+
+func waitBatchDone(t *testing.T, done chan string, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for batch, %d of %d done", i, n)
+		}
+	}
+}
+
+var testBatchDriver = &lbcfapi.LoadBalancerDriver{
+	ObjectMeta: metav1.ObjectMeta{Name: "test-driver", Namespace: "kube-system"},
+}
+
+func TestBackendBatcherNotLimitedByWorkers(t *testing.T) {
+	invoker := &fakeBatchInvoker{}
+	b, done := newTestBatcher(invoker)
+
+	// a single worker submits all requests without waiting for the batch
+	total := maxBackendBatchSize + 50
+	for i := 0; i < total; i++ {
+		key, req := newTestBatchRequest(i)
+		if _, ok, err := b.call(key, testBatchDriver, webhooks.BatchEnsureBackend, req); ok || err != nil {
+			t.Fatalf("expect request %d to be pending, got done %v, err %v", i, ok, err)
+		}
+	}
+	waitBatchDone(t, done, total)
+
+	sizes := invoker.sizes()
+	if len(sizes) != 2 || sizes[0]+sizes[1] != total ||
+		(sizes[0] != maxBackendBatchSize && sizes[1] != maxBackendBatchSize) {
+		t.Fatalf("expect a full batch and a batch of the rest, got %v", sizes)
+	}
+
+	for i := 0; i < total; i++ {
+		key, req := newTestBatchRequest(i)
+		rsp, ok, err := b.call(key, testBatchDriver, webhooks.BatchEnsureBackend, req)
+		if !ok || err != nil {
+			t.Fatalf("expect result of request %d, got done %v, err %v", i, ok, err)
+		}
+		if rsp.Status != webhooks.StatusSucc || rsp.InjectedInfo["addr"] != req.BackendAddr {
+			t.Fatalf("unexpected response for request %d: %+v", i, rsp)
+		}
+	}
+	if len(b.results) != 0 || b.pending.Len() != 0 {
+		t.Errorf("expect results to be taken, %d results and %d pending left", len(b.results), b.pending.Len())
+	}
+}
+
+func TestBackendBatcherPendingRequest(t *testing.T) {
+	invoker := &fakeBatchInvoker{}
+	b, done := newTestBatcher(invoker)
+
+	key, req := newTestBatchRequest(1)
+	for i := 0; i < 3; i++ {
+		if _, ok, _ := b.call(key, testBatchDriver, webhooks.BatchEnsureBackend, req); ok {
+			t.Fatalf("expect request to be pending")
+		}
+	}
+	waitBatchDone(t, done, 1)
+	if sizes := invoker.sizes(); len(sizes) != 1 || sizes[0] != 1 {
+		t.Fatalf("expect pending request to be sent once, got %v", sizes)
+	}
+}
+
+func TestBackendBatcherDiscardsOutdatedResult(t *testing.T) {
+	invoker := &fakeBatchInvoker{}
+	b, done := newTestBatcher(invoker)
+
+	key, req := newTestBatchRequest(1)
+	b.call(key, testBatchDriver, webhooks.BatchEnsureBackend, req)
+	waitBatchDone(t, done, 1)
+
+	weight := int32(10)
+	req.Weight = &weight
+	if _, ok, _ := b.call(key, testBatchDriver, webhooks.BatchEnsureBackend, req); ok {
+		t.Fatalf("expect result of outdated request to be discarded")
+	}
+	waitBatchDone(t, done, 1)
+	if _, ok, err := b.call(key, testBatchDriver, webhooks.BatchEnsureBackend, req); !ok || err != nil {
+		t.Fatalf("expect result of updated request, got done %v, err %v", ok, err)
+	}
+	if sizes := invoker.sizes(); len(sizes) != 2 {
+		t.Errorf("expect 2 batches, got %v", sizes)
+	}
+}
+
+func TestBackendBatcherError(t *testing.T) {
+	invoker := &fakeBatchInvoker{err: fmt.Errorf("driver down")}
+	b, done := newTestBatcher(invoker)
+
+	key, req := newTestBatchRequest(1)
+	b.call(key, testBatchDriver, webhooks.BatchEnsureBackend, req)
+	waitBatchDone(t, done, 1)
+	if _, ok, err := b.call(key, testBatchDriver, webhooks.BatchEnsureBackend, req); !ok || err == nil {
+		t.Fatalf("expect error of batch to be returned, got done %v, err %v", ok, err)
+	}
+}
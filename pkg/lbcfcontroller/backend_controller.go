@@ -20,6 +20,7 @@ package lbcfcontroller
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
 	lbcfclient "tkestack.io/lb-controlling-framework/pkg/client-go/clientset/versioned"
@@ -30,22 +31,30 @@ import (
 	apicore "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
 	corev1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 )
 
 func newBackendController(client lbcfclient.Interface,
+	k8sClient kubernetes.Interface,
 	brLister v1beta1.BackendRecordLister,
 	driverLister v1beta1.LoadBalancerDriverLister,
 	podLister corev1.PodLister,
 	svcLister corev1.ServiceLister,
 	nodeLister corev1.NodeLister,
 	recorder record.EventRecorder,
-	invoker util.WebhookInvoker) *backendController {
+	invoker util.WebhookInvoker,
+	inFlightLimiter *util.InFlightLimiter,
+	drainer *podDrainer,
+	batchWindow time.Duration) *backendController {
 	return &backendController{
 		client:             client,
+		k8sClient:          k8sClient,
 		brLister:           brLister,
 		driverLister:       driverLister,
 		podLister:          podLister,
@@ -54,11 +63,15 @@ func newBackendController(client lbcfclient.Interface,
 		eventRecorder:      recorder,
 		inProgressDeleting: new(sync.Map),
 		webhookInvoker:     invoker,
+		inFlightLimiter:    inFlightLimiter,
+		podDrainer:         drainer,
+		batcher:            newBackendBatcher(invoker, batchWindow),
 	}
 }
 
 type backendController struct {
 	client        lbcfclient.Interface
+	k8sClient     kubernetes.Interface
 	brLister      v1beta1.BackendRecordLister
 	driverLister  v1beta1.LoadBalancerDriverLister
 	podLister     corev1.PodLister
@@ -68,6 +81,9 @@ type backendController struct {
 
 	inProgressDeleting *sync.Map
 	webhookInvoker     util.WebhookInvoker
+	inFlightLimiter    *util.InFlightLimiter
+	podDrainer         *podDrainer
+	batcher            *backendBatcher
 }
 
 func (c *backendController) syncBackendRecord(key string) *util.SyncResult {
@@ -83,11 +99,29 @@ func (c *backendController) syncBackendRecord(key string) *util.SyncResult {
 		return util.ErrorResult(err)
 	}
 
-	if backend.DeletionTimestamp != nil {
-		if !util.HasFinalizer(backend.Finalizers, lbcfapi.FinalizerDeregisterBackend) {
-			c.removeDeletingRecord(backend)
-			return util.FinishedResult()
+	if backend.DeletionTimestamp != nil && !util.HasFinalizer(backend.Finalizers, lbcfapi.FinalizerDeregisterBackend) {
+		c.removeDeletingRecord(backend)
+		return util.FinishedResult()
+	}
+
+	if backend.DeletionTimestamp != nil && util.NeedDrain(backend) {
+		if cond := util.GetBackendRecordCondition(&backend.Status, lbcfapi.BackendDrained); cond != nil {
+			return c.waitDrain(backend, cond)
 		}
+	}
+
+	driverNamespace := util.GetDriverNamespace(backend.Spec.LBDriver, util.GetBackendLBNamespace(backend))
+	driverKey := util.NamespacedNameKeyFunc(driverNamespace, backend.Spec.LBDriver)
+	driver, err := c.driverLister.LoadBalancerDrivers(driverNamespace).Get(backend.Spec.LBDriver)
+	if err == nil && !util.IsDriverHealthy(driver) {
+		return util.FailResult(util.DriverUnhealthyRetryDelay, fmt.Sprintf("driver %s is unhealthy", driverKey))
+	}
+	if !c.inFlightLimiter.TryAcquire(driverKey) {
+		return util.ThrottledResult()
+	}
+	defer c.inFlightLimiter.Release(driverKey)
+
+	if backend.DeletionTimestamp != nil {
 		return c.deregisterBackend(backend)
 	}
 
@@ -98,8 +132,12 @@ func (c *backendController) syncBackendRecord(key string) *util.SyncResult {
 }
 
 func (c *backendController) generateBackendAddr(backend *lbcfapi.BackendRecord) *util.SyncResult {
+	// a BackendRecord without address is not registered, e.g. it is recreated
+	if err := c.syncPodRegistered(backend); err != nil {
+		return util.ErrorResult(err)
+	}
 	driver, err := c.driverLister.LoadBalancerDrivers(
-		util.GetDriverNamespace(backend.Spec.LBDriver, backend.Namespace)).Get(backend.Spec.LBDriver)
+		util.GetDriverNamespace(backend.Spec.LBDriver, util.GetBackendLBNamespace(backend))).Get(backend.Spec.LBDriver)
 	if err != nil {
 		return util.ErrorResult(
 			fmt.Errorf("retrieve driver %q for BackendRecord %s failed: %v",
@@ -110,12 +148,17 @@ func (c *backendController) generateBackendAddr(backend *lbcfapi.BackendRecord)
 	if backend.Spec.PodBackendInfo != nil {
 		rsp, err = c.generatePodAddr(backend, driver)
 		if err != nil {
-			return util.ErrorResult(err)
+			return util.WebhookErrorResult(err)
 		}
 	} else if backend.Spec.ServiceBackendInfo != nil {
 		rsp, err = c.generateServiceAddr(backend, driver)
 		if err != nil {
-			return util.ErrorResult(err)
+			return util.WebhookErrorResult(err)
+		}
+	} else if backend.Spec.NodeBackendInfo != nil {
+		rsp, err = c.generateNodeAddr(backend, driver)
+		if err != nil {
+			return util.WebhookErrorResult(err)
 		}
 	} else if backend.Spec.StaticAddr != nil {
 		rsp, _ = c.generateStaticAddr(backend)
@@ -178,7 +221,7 @@ func (c *backendController) ensureBackend(backend *lbcfapi.BackendRecord) *util.
 	}
 
 	driver, err := c.driverLister.LoadBalancerDrivers(
-		util.GetDriverNamespace(backend.Spec.LBDriver, backend.Namespace)).Get(backend.Spec.LBDriver)
+		util.GetDriverNamespace(backend.Spec.LBDriver, util.GetBackendLBNamespace(backend))).Get(backend.Spec.LBDriver)
 	if err != nil {
 		return util.ErrorResult(
 			fmt.Errorf("retrieve driver %q for BackendRecord %s failed: %v",
@@ -194,10 +237,22 @@ func (c *backendController) ensureBackend(backend *lbcfapi.BackendRecord) *util.
 		BackendAddr:  backend.Status.BackendAddr,
 		Parameters:   backend.Spec.Parameters,
 		InjectedInfo: backend.Status.InjectedInfo,
+		Weight:       backend.Spec.Weight,
+	}
+	var rsp *webhooks.BackendOperationResponse
+	if util.DriverImplements(driver, webhooks.BatchEnsureBackend) {
+		var done bool
+		rsp, done, err = c.batcher.call(util.NamespacedNameKeyFunc(backend.Namespace, backend.Name),
+			driver, webhooks.BatchEnsureBackend, req)
+		if err == nil && !done {
+			// the BackendRecord is enqueued again once the batch is sent
+			return util.FinishedResult()
+		}
+	} else {
+		rsp, err = c.webhookInvoker.CallEnsureBackend(driver, req)
 	}
-	rsp, err := c.webhookInvoker.CallEnsureBackend(driver, req)
 	if err != nil {
-		return util.ErrorResult(err)
+		return util.WebhookErrorResult(err)
 	}
 	switch rsp.Status {
 	case webhooks.StatusSucc:
@@ -223,6 +278,9 @@ func (c *backendController) ensureBackend(backend *lbcfapi.BackendRecord) *util.
 			apicore.EventTypeNormal,
 			"SuccEnsureBackend",
 			"Successfully ensured backend")
+		if err := c.syncPodRegistered(backend); err != nil {
+			return util.ErrorResult(err)
+		}
 		if backend.Spec.EnsurePolicy != nil && backend.Spec.EnsurePolicy.Policy == lbcfapi.PolicyAlways {
 			return util.PeriodicResult(util.GetDuration(backend.Spec.EnsurePolicy.MinPeriod, util.DefaultEnsurePeriod))
 		}
@@ -248,6 +306,9 @@ func (c *backendController) ensureBackend(backend *lbcfapi.BackendRecord) *util.
 			apicore.EventTypeWarning,
 			"FailedEnsureBackend",
 			"msg: %s", rsp.Msg)
+		if err := c.syncPodRegistered(backend); err != nil {
+			return util.ErrorResult(err)
+		}
 		return util.FailResult(util.CalculateRetryInterval(rsp.MinRetryDelayInSeconds), rsp.Msg)
 	case webhooks.StatusRunning:
 		c.eventRecorder.Eventf(backend,
@@ -265,15 +326,85 @@ func (c *backendController) ensureBackend(backend *lbcfapi.BackendRecord) *util.
 	}
 }
 
+// syncPodRegistered sets pod condition lbcf.tke.cloud.tencent.com/registered of the pod that backend belongs to.
+// The condition is True if the pod has BackendRecords with readinessGate enabled and all of them are registered,
+// deleting BackendRecords are not counted, so the condition becomes False once a BackendRecord starts deregistering
+// unless the pod is registered by other BackendRecords, e.g. the BackendRecord of a new LoadBalancer.
+func (c *backendController) syncPodRegistered(backend *lbcfapi.BackendRecord) error {
+	if backend.Spec.PodBackendInfo == nil || !backend.Spec.PodBackendInfo.ReadinessGate {
+		return nil
+	}
+	pod, err := c.podLister.Pods(backend.Namespace).Get(backend.Spec.PodBackendInfo.Name)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	registered, err := c.isPodRegistered(pod, backend)
+	if err != nil {
+		return err
+	}
+	status := apicore.ConditionFalse
+	if registered {
+		status = apicore.ConditionTrue
+	}
+	_, cond := podutil.GetPodCondition(&pod.Status, lbcfapi.PodConditionRegistered)
+	if cond != nil && cond.Status == status {
+		return nil
+	}
+	if cond == nil && status == apicore.ConditionFalse {
+		// a missing condition is treated as False by kubelet
+		return nil
+	}
+
+	pod = pod.DeepCopy()
+	podutil.UpdatePodCondition(&pod.Status, &apicore.PodCondition{
+		Type:   lbcfapi.PodConditionRegistered,
+		Status: status,
+	})
+	if _, err := c.k8sClient.CoreV1().Pods(pod.Namespace).UpdateStatus(pod); err != nil {
+		return fmt.Errorf("update condition %s of pod %s/%s to %s failed: %v",
+			lbcfapi.PodConditionRegistered, pod.Namespace, pod.Name, status, err)
+	}
+	return nil
+}
+
+// isPodRegistered returns true if all BackendRecords of pod with readinessGate enabled are registered,
+// the status of backend is used instead of the one in lister, because the lister may be outdated
+func (c *backendController) isPodRegistered(pod *apicore.Pod, backend *lbcfapi.BackendRecord) (bool, error) {
+	selector := labels.SelectorFromSet(labels.Set{lbcfapi.LabelPodName: pod.Name})
+	records, err := c.brLister.BackendRecords(backend.Namespace).List(selector)
+	if err != nil {
+		return false, err
+	}
+	gated := 0
+	for _, r := range append(records, backend) {
+		if r != backend && r.Name == backend.Name {
+			continue
+		}
+		if r.DeletionTimestamp != nil || r.Spec.PodBackendInfo == nil || !r.Spec.PodBackendInfo.ReadinessGate {
+			continue
+		}
+		if !util.BackendRegistered(r) {
+			return false, nil
+		}
+		gated++
+	}
+	return gated > 0, nil
+}
+
 func (c *backendController) deregisterBackend(backend *lbcfapi.BackendRecord) *util.SyncResult {
 	c.storeDeletingBackend(backend)
+	if err := c.syncPodRegistered(backend); err != nil {
+		return util.ErrorResult(err)
+	}
 
 	if backend.Status.BackendAddr == "" {
 		return c.removeFinalizer(backend)
 	}
 
 	driver, err := c.driverLister.LoadBalancerDrivers(
-		util.GetDriverNamespace(backend.Spec.LBDriver, backend.Namespace)).Get(backend.Spec.LBDriver)
+		util.GetDriverNamespace(backend.Spec.LBDriver, util.GetBackendLBNamespace(backend))).Get(backend.Spec.LBDriver)
 	if err != nil {
 		return util.ErrorResult(
 			fmt.Errorf("retrieve driver %q for BackendRecord %s failed: %v",
@@ -289,12 +420,26 @@ func (c *backendController) deregisterBackend(backend *lbcfapi.BackendRecord) *u
 		Parameters:   backend.Spec.Parameters,
 		InjectedInfo: backend.Status.InjectedInfo,
 	}
-	rsp, err := c.webhookInvoker.CallDeregisterBackend(driver, req)
+	var rsp *webhooks.BackendOperationResponse
+	if util.DriverImplements(driver, webhooks.BatchDeregBackend) {
+		var done bool
+		rsp, done, err = c.batcher.call(util.NamespacedNameKeyFunc(backend.Namespace, backend.Name),
+			driver, webhooks.BatchDeregBackend, req)
+		if err == nil && !done {
+			// the BackendRecord is enqueued again once the batch is sent
+			return util.FinishedResult()
+		}
+	} else {
+		rsp, err = c.webhookInvoker.CallDeregisterBackend(driver, req)
+	}
 	if err != nil {
-		return util.ErrorResult(err)
+		return util.WebhookErrorResult(err)
 	}
 	switch rsp.Status {
 	case webhooks.StatusSucc:
+		if util.NeedDrain(backend) {
+			return c.startDrain(backend, rsp.Msg)
+		}
 		return c.removeFinalizer(backend)
 	case webhooks.StatusFail:
 		c.eventRecorder.Eventf(backend,
@@ -330,6 +475,73 @@ func (c *backendController) removeFinalizer(backend *lbcfapi.BackendRecord) *uti
 	return util.FinishedResult()
 }
 
+// startDrain records the start of draining in status, the finalizer is removed after drainPeriod
+func (c *backendController) startDrain(backend *lbcfapi.BackendRecord, msg string) *util.SyncResult {
+	period := backend.Spec.PodBackendInfo.DrainPeriod.Duration
+	now := v1.Now()
+	backend = backend.DeepCopy()
+	util.AddBackendCondition(&backend.Status, lbcfapi.BackendRecordCondition{
+		Type:               lbcfapi.BackendRegistered,
+		Status:             lbcfapi.ConditionFalse,
+		LastTransitionTime: now,
+		Message:            msg,
+	})
+	util.AddBackendCondition(&backend.Status, lbcfapi.BackendRecordCondition{
+		Type:               lbcfapi.BackendDrained,
+		Status:             lbcfapi.ConditionFalse,
+		LastTransitionTime: now,
+		Reason:             lbcfapi.ReasonDraining.String(),
+		Message:            fmt.Sprintf("draining until %s", now.Add(period).Format(time.RFC3339)),
+	})
+	_, err := c.client.LbcfV1beta1().BackendRecords(backend.Namespace).UpdateStatus(backend)
+	if err != nil {
+		c.eventRecorder.Eventf(backend,
+			apicore.EventTypeWarning,
+			"FailedDeregister",
+			"update status failed: %v", err)
+		return util.ErrorResult(err)
+	}
+	c.eventRecorder.Eventf(backend,
+		apicore.EventTypeNormal,
+		"StartDrain",
+		"backend deregistered, draining for %s", period.String())
+	return util.AsyncResult(period)
+}
+
+// waitDrain sets condition Drained to True and removes the finalizer if drainPeriod has passed since deregistration
+func (c *backendController) waitDrain(backend *lbcfapi.BackendRecord,
+	cond *lbcfapi.BackendRecordCondition) *util.SyncResult {
+	if cond.Status != lbcfapi.ConditionTrue {
+		remaining := cond.LastTransitionTime.Add(backend.Spec.PodBackendInfo.DrainPeriod.Duration).Sub(time.Now())
+		if remaining > 0 {
+			return util.AsyncResult(remaining)
+		}
+		backend = backend.DeepCopy()
+		util.AddBackendCondition(&backend.Status, lbcfapi.BackendRecordCondition{
+			Type:               lbcfapi.BackendDrained,
+			Status:             lbcfapi.ConditionTrue,
+			LastTransitionTime: v1.Now(),
+			Message:            "drained",
+		})
+		updated, err := c.client.LbcfV1beta1().BackendRecords(backend.Namespace).UpdateStatus(backend)
+		if err != nil {
+			return util.ErrorResult(err)
+		}
+		backend = updated
+		c.eventRecorder.Eventf(backend,
+			apicore.EventTypeNormal,
+			"SuccDrain",
+			"backend drained")
+	}
+	result := c.removeFinalizer(backend)
+	if result.IsFinished() {
+		if err := c.podDrainer.releaseByName(backend.Namespace, backend.Spec.PodBackendInfo.Name, backend.Name); err != nil {
+			return util.ErrorResult(err)
+		}
+	}
+	return result
+}
+
 func (c *backendController) storeDeletingBackend(backend *lbcfapi.BackendRecord) {
 	key := fmt.Sprintf("%s|%s", backend.Spec.LBInfo, backend.Status.BackendAddr)
 	value := util.NamespacedNameKeyFunc(backend.Namespace, backend.Name)
@@ -415,6 +627,27 @@ func (c *backendController) generateServiceAddr(backend *lbcfapi.BackendRecord,
 	return c.webhookInvoker.CallGenerateBackendAddr(driver, req)
 }
 
+func (c *backendController) generateNodeAddr(backend *lbcfapi.BackendRecord,
+	driver *lbcfapi.LoadBalancerDriver) (*webhooks.GenerateBackendAddrResponse, error) {
+	node, err := c.nodeLister.Get(backend.Spec.NodeBackendInfo.Name)
+	if err != nil {
+		return nil, err
+	}
+	req := &webhooks.GenerateBackendAddrRequest{
+		RequestForRetryHooks: webhooks.RequestForRetryHooks{
+			RecordID: fmt.Sprintf("generateBackendAddr(%s)", backend.UID),
+			RetryID:  string(uuid.NewUUID()),
+		},
+		LBInfo:       backend.Spec.LBInfo,
+		LBAttributes: backend.Spec.LBAttributes,
+		NodeBackend: &webhooks.NodeBackendInGenerateAddrRequest{
+			Node: *node,
+			Port: backend.Spec.NodeBackendInfo.Port,
+		},
+	}
+	return c.webhookInvoker.CallGenerateBackendAddr(driver, req)
+}
+
 func (c *backendController) generateStaticAddr(backend *lbcfapi.BackendRecord) (*webhooks.GenerateBackendAddrResponse,
 	error) {
 	rsp := &webhooks.GenerateBackendAddrResponse{}
@@ -0,0 +1,203 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package lbcfcontroller
+
+import (
+	"testing"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	lbcflister "tkestack.io/lb-controlling-framework/pkg/client-go/listers/lbcf.tke.cloud.tencent.com/v1beta1"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1 "k8s.io/client-go/listers/core/v1"
+)
+
+func newTestGatedRecord(name string, podName string, registered bool, deleting bool) *lbcfapi.BackendRecord {
+	record := &lbcfapi.BackendRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{lbcfapi.LabelPodName: podName},
+		},
+		Spec: lbcfapi.BackendRecordSpec{
+			PodBackendInfo: &lbcfapi.PodBackendRecord{
+				Name:          podName,
+				ReadinessGate: true,
+			},
+		},
+	}
+	status := lbcfapi.ConditionFalse
+	if registered {
+		status = lbcfapi.ConditionTrue
+	}
+	record.Status.Conditions = []lbcfapi.BackendRecordCondition{
+		{Type: lbcfapi.BackendRegistered, Status: status},
+	}
+	if deleting {
+		now := metav1.Now()
+		record.DeletionTimestamp = &now
+	}
+	return record
+}
+
+func TestSyncPodRegistered(t *testing.T) {
+	cases := []struct {
+		name    string
+		podCond *v1.ConditionStatus
+		backend *lbcfapi.BackendRecord
+		// records in lister, backend is not included unless it is listed here
+		records []*lbcfapi.BackendRecord
+		// nil means pod status is not updated
+		expect *v1.ConditionStatus
+	}{
+		{
+			name:    "registered",
+			backend: newTestGatedRecord("r1", "pod", true, false),
+			records: []*lbcfapi.BackendRecord{newTestGatedRecord("r1", "pod", false, false)},
+			expect:  conditionStatusPtr(v1.ConditionTrue),
+		},
+		{
+			name:    "other-record-not-registered",
+			backend: newTestGatedRecord("r1", "pod", true, false),
+			records: []*lbcfapi.BackendRecord{
+				newTestGatedRecord("r1", "pod", true, false),
+				newTestGatedRecord("r2", "pod", false, false),
+			},
+		},
+		{
+			name:    "already-true",
+			podCond: conditionStatusPtr(v1.ConditionTrue),
+			backend: newTestGatedRecord("r1", "pod", true, false),
+			records: []*lbcfapi.BackendRecord{newTestGatedRecord("r1", "pod", true, false)},
+		},
+		{
+			name:    "failed-again",
+			podCond: conditionStatusPtr(v1.ConditionTrue),
+			backend: newTestGatedRecord("r1", "pod", false, false),
+			records: []*lbcfapi.BackendRecord{newTestGatedRecord("r1", "pod", true, false)},
+			expect:  conditionStatusPtr(v1.ConditionFalse),
+		},
+		{
+			name:    "recreated-record-not-in-lister",
+			podCond: conditionStatusPtr(v1.ConditionTrue),
+			backend: newTestGatedRecord("r2", "pod", false, false),
+			records: []*lbcfapi.BackendRecord{newTestGatedRecord("r1", "pod", true, false)},
+			expect:  conditionStatusPtr(v1.ConditionFalse),
+		},
+		{
+			name:    "deregistering",
+			podCond: conditionStatusPtr(v1.ConditionTrue),
+			backend: newTestGatedRecord("r1", "pod", true, true),
+			records: []*lbcfapi.BackendRecord{newTestGatedRecord("r1", "pod", true, true)},
+			expect:  conditionStatusPtr(v1.ConditionFalse),
+		},
+		{
+			name:    "lb-changed-new-record-registered",
+			podCond: conditionStatusPtr(v1.ConditionTrue),
+			backend: newTestGatedRecord("old", "pod", true, true),
+			records: []*lbcfapi.BackendRecord{
+				newTestGatedRecord("old", "pod", true, true),
+				newTestGatedRecord("new", "pod", true, false),
+			},
+		},
+		{
+			name:    "lb-changed-new-record-not-registered",
+			podCond: conditionStatusPtr(v1.ConditionTrue),
+			backend: newTestGatedRecord("old", "pod", true, true),
+			records: []*lbcfapi.BackendRecord{
+				newTestGatedRecord("old", "pod", true, true),
+				newTestGatedRecord("new", "pod", false, false),
+			},
+			expect: conditionStatusPtr(v1.ConditionFalse),
+		},
+		{
+			name:    "missing-condition-not-set-to-false",
+			backend: newTestGatedRecord("r1", "pod", false, false),
+		},
+	}
+	for _, c := range cases {
+		pod := newTestPod("pod", false)
+		if c.podCond != nil {
+			pod.Status.Conditions = []v1.PodCondition{{Type: lbcfapi.PodConditionRegistered, Status: *c.podCond}}
+		}
+		var brObjs []interface{}
+		for _, r := range c.records {
+			brObjs = append(brObjs, r)
+		}
+		client := fake.NewSimpleClientset(pod)
+		ctrl := &backendController{
+			k8sClient: client,
+			podLister: corev1.NewPodLister(newTestIndexer(pod)),
+			brLister:  lbcflister.NewBackendRecordLister(newTestIndexer(brObjs...)),
+		}
+		if err := ctrl.syncPodRegistered(c.backend); err != nil {
+			t.Fatalf("case %s: expect no error, got %v", c.name, err)
+		}
+
+		updated := false
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "update" && action.GetSubresource() == "status" {
+				updated = true
+			}
+		}
+		if c.expect == nil {
+			if updated {
+				t.Errorf("case %s: expect pod status not updated", c.name)
+			}
+			continue
+		}
+		if !updated {
+			t.Errorf("case %s: expect pod status updated", c.name)
+			continue
+		}
+		var got v1.ConditionStatus
+		for _, cond := range getTestPod(t, client, "pod").Status.Conditions {
+			if cond.Type == lbcfapi.PodConditionRegistered {
+				got = cond.Status
+			}
+		}
+		if got != *c.expect {
+			t.Errorf("case %s: expect condition %s, got %q", c.name, *c.expect, got)
+		}
+	}
+}
+
+func TestSyncPodRegisteredIgnoresNotGatedRecords(t *testing.T) {
+	pod := newTestPod("pod", false)
+	pod.Status.Conditions = []v1.PodCondition{{Type: lbcfapi.PodConditionRegistered, Status: v1.ConditionTrue}}
+	backend := newTestGatedRecord("r1", "pod", false, false)
+	backend.Spec.PodBackendInfo.ReadinessGate = false
+	client := fake.NewSimpleClientset(pod)
+	ctrl := &backendController{
+		k8sClient: client,
+		podLister: corev1.NewPodLister(newTestIndexer(pod)),
+		brLister:  lbcflister.NewBackendRecordLister(newTestIndexer()),
+	}
+	if err := ctrl.syncPodRegistered(backend); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if len(client.Actions()) != 0 {
+		t.Errorf("expect no action, got %v", client.Actions())
+	}
+}
+
+func conditionStatusPtr(status v1.ConditionStatus) *v1.ConditionStatus {
+	return &status
+}
@@ -19,12 +19,14 @@ package lbcfcontroller
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"sync"
 
 	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
 	lbcfclient "tkestack.io/lb-controlling-framework/pkg/client-go/clientset/versioned"
 	lbcflister "tkestack.io/lb-controlling-framework/pkg/client-go/listers/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/endpointslice"
 	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
 
 	"k8s.io/api/core/v1"
@@ -46,7 +48,9 @@ func newBackendGroupController(
 	brLister lbcflister.BackendRecordLister,
 	podLister corev1.PodLister,
 	svcLister corev1.ServiceLister,
-	nodeLister corev1.NodeLister) *backendGroupController {
+	nodeLister corev1.NodeLister,
+	endpointSliceLister endpointslice.Lister,
+	drainer *podDrainer) *backendGroupController {
 	return &backendGroupController{
 		client:              client,
 		lbLister:            lbLister,
@@ -55,6 +59,8 @@ func newBackendGroupController(
 		podLister:           podLister,
 		serviceLister:       svcLister,
 		nodeLister:          nodeLister,
+		endpointSliceLister: endpointSliceLister,
+		podDrainer:          drainer,
 		relatedLoadBalancer: &sync.Map{},
 		relatedPod:          &sync.Map{},
 	}
@@ -69,6 +75,9 @@ type backendGroupController struct {
 	podLister     corev1.PodLister
 	serviceLister corev1.ServiceLister
 	nodeLister    corev1.NodeLister
+	// endpointSliceLister is nil if discovery.k8s.io/v1 is not served
+	endpointSliceLister endpointslice.Lister
+	podDrainer          *podDrainer
 
 	relatedLoadBalancer *sync.Map
 	relatedPod          *sync.Map
@@ -92,7 +101,7 @@ func (c *backendGroupController) syncBackendGroup(key string) *util.SyncResult {
 	}
 
 	// compare graph
-	lb, err := c.lbLister.LoadBalancers(namespace).Get(group.Spec.LBName)
+	lb, err := c.lbLister.LoadBalancers(util.GetLBNamespace(group)).Get(group.Spec.LBName)
 	if errors.IsNotFound(err) {
 		return c.deleteAllBackend(namespace, group.Spec.LBName, group.Name)
 	} else if err != nil {
@@ -102,30 +111,63 @@ func (c *backendGroupController) syncBackendGroup(key string) *util.SyncResult {
 	if lb.DeletionTimestamp != nil {
 		return c.deleteAllBackend(namespace, group.Spec.LBName, group.Name)
 	}
+	if !util.IsNamespaceAllowed(lb, namespace) {
+		klog.Warningf("BackendGroup %s/%s is not allowed to use LoadBalancer %s/%s, deregister all backends",
+			namespace, name, lb.Namespace, lb.Name)
+		return c.deleteAllBackend(namespace, group.Spec.LBName, group.Name)
+	}
 	if !util.LBCreated(lb) {
 		return util.FinishedResult()
 	}
 
-	var expectedBackends []*lbcfapi.BackendRecord
+	var pods []*v1.Pod
 	if group.Spec.Pods != nil {
-		expectedBackends, err = c.expectedPodBackends(group, lb)
-	} else if group.Spec.Service != nil {
-		expectedBackends, err = c.expectedServiceBackends(group, lb)
-	} else {
-		expectedBackends, err = c.expectedStaticBackends(group, lb)
+		if pods, err = c.listPods(group); err != nil {
+			return util.ErrorResult(err)
+		}
 	}
-	if err != nil {
-		return util.ErrorResult(err)
+
+	// expectedBackends contains one slice of BackendRecords for each port
+	var expectedBackends [][]*lbcfapi.BackendRecord
+	ports := util.GetBackendPorts(group)
+	for _, port := range ports {
+		var backends []*lbcfapi.BackendRecord
+		if group.Spec.Pods != nil {
+			backends = c.expectedPodBackends(group, lb, pods, port)
+		} else if group.Spec.Service != nil {
+			backends, err = c.expectedServiceBackends(group, lb, port)
+		} else {
+			backends, err = c.expectedNodeBackends(group, lb)
+		}
+		if err != nil {
+			return util.ErrorResult(err)
+		}
+		expectedBackends = append(expectedBackends, backends)
 	}
-	return c.update(group, lb, expectedBackends)
+	if len(ports) == 0 {
+		backends, err := c.expectedStaticBackends(group, lb)
+		if err != nil {
+			return util.ErrorResult(err)
+		}
+		expectedBackends = append(expectedBackends, backends)
+	}
+	result := c.update(group, lb, ports, expectedBackends)
+	if util.IsDrainModeEnabled(group) {
+		if err := c.podDrainer.sync(pods); err != nil {
+			return util.ErrorResult(err)
+		}
+	}
+	return result
 }
 
-func (c *backendGroupController) expectedPodBackends(group *lbcfapi.BackendGroup,
-	lb *lbcfapi.LoadBalancer) ([]*lbcfapi.BackendRecord, error) {
+func (c *backendGroupController) listPods(group *lbcfapi.BackendGroup) ([]*v1.Pod, error) {
 	var pods []*v1.Pod
 	if group.Spec.Pods.ByLabel != nil {
-		var err error
-		pods, err = c.podLister.List(labels.SelectorFromSet(labels.Set(group.Spec.Pods.ByLabel.Selector)))
+		selector, err := util.PodSelector(group.Spec.Pods.ByLabel)
+		if err != nil {
+			return nil, err
+		}
+		pods, err = c.podLister.List(selector)
 		if err != nil {
 			return nil, err
 		}
@@ -148,21 +190,26 @@ func (c *backendGroupController) expectedPodBackends(group *lbcfapi.BackendGroup
 			pods = append(pods, pod)
 		}
 	}
+	return pods, nil
+}
 
+func (c *backendGroupController) expectedPodBackends(group *lbcfapi.BackendGroup,
+	lb *lbcfapi.LoadBalancer, pods []*v1.Pod, port lbcfapi.PortSelector) []*lbcfapi.BackendRecord {
 	var expectedRecords []*lbcfapi.BackendRecord
 	for _, pod := range util.FilterPods(pods, util.PodAvailable) {
-		record := util.ConstructPodBackendRecord(lb, group, pod)
+		record := util.ConstructPodBackendRecord(lb, group, pod, port)
+		if record == nil {
+			klog.Infof("port not found in pod %s/%s. looking for: %s/%s",
+				pod.Namespace, pod.Name, port.PortName, port.Protocol)
+			continue
+		}
 		expectedRecords = append(expectedRecords, record)
 	}
-	return expectedRecords, nil
+	return expectedRecords
 }
 
 func (c *backendGroupController) expectedServiceBackends(group *lbcfapi.BackendGroup,
-	lb *lbcfapi.LoadBalancer) ([]*lbcfapi.BackendRecord, error) {
-	nodes, err := c.nodeLister.List(labels.SelectorFromSet(labels.Set(group.Spec.Service.NodeSelector)))
-	if err != nil {
-		return nil, err
-	}
+	lb *lbcfapi.LoadBalancer, port lbcfapi.PortSelector) ([]*lbcfapi.BackendRecord, error) {
 	svc, err := c.serviceLister.Services(group.Namespace).Get(group.Spec.Service.Name)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -170,16 +217,42 @@ func (c *backendGroupController) expectedServiceBackends(group *lbcfapi.BackendG
 		}
 		return nil, err
 	}
-	if svc.DeletionTimestamp != nil || svc.Spec.Type != v1.ServiceTypeNodePort {
+	if svc.DeletionTimestamp != nil {
 		return nil, nil
 	}
+	if group.Spec.Service.Mode == lbcfapi.ServiceModeEndpointPod {
+		return c.expectedEndpointPodBackends(group, lb, svc, port)
+	}
+	if svc.Spec.Type != v1.ServiceTypeNodePort {
+		return nil, nil
+	}
+	selector, err := util.ServiceNodeSelector(group.Spec.Service)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := c.nodeLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	if group.Spec.Service.Mode == lbcfapi.ServiceModeEndpointNode {
+		hosts, err := c.listEndpointNodes(svc, port)
+		if err != nil {
+			return nil, err
+		}
+		var filtered []*v1.Node
+		for _, node := range nodes {
+			if hosts.Has(node.Name) {
+				filtered = append(filtered, node)
+			}
+		}
+		nodes = filtered
+	}
 	var expectedRecords []*lbcfapi.BackendRecord
 	for _, node := range nodes {
-		backend := util.ConstructServiceBackendRecord(lb, group, svc, node)
+		backend := util.ConstructServiceBackendRecord(lb, group, svc, node, port)
 		if backend == nil {
-			klog.Infof("servicePort not found in svc %s/%s. looking for: %d/%s",
-				svc.Namespace, svc.Name,
-				group.Spec.Service.Port.PortNumber, group.Spec.Service.Port.Protocol)
+			klog.Infof("servicePort not found in svc %s/%s. looking for: %+v",
+				svc.Namespace, svc.Name, port)
 			continue
 		}
 		expectedRecords = append(expectedRecords, backend)
@@ -187,6 +260,98 @@ func (c *backendGroupController) expectedServiceBackends(group *lbcfapi.BackendG
 	return expectedRecords, nil
 }
 
+// expectedEndpointPodBackends returns BackendRecords for ready pod endpoints that serve the selected service port
+func (c *backendGroupController) expectedEndpointPodBackends(group *lbcfapi.BackendGroup,
+	lb *lbcfapi.LoadBalancer, svc *v1.Service, port lbcfapi.PortSelector) ([]*lbcfapi.BackendRecord, error) {
+	var expectedRecords []*lbcfapi.BackendRecord
+	err := c.iterateReadyEndpoints(svc, port, func(ep endpointslice.Endpoint, port lbcfapi.PortSelector) {
+		if ep.TargetRef == nil || ep.TargetRef.Kind != "Pod" {
+			return
+		}
+		expectedRecords = append(expectedRecords, util.ConstructEndpointPodBackendRecord(lb, group, svc, ep.TargetRef, port))
+	})
+	return expectedRecords, err
+}
+
+// listEndpointNodes returns names of nodes that host ready endpoints serving the selected service port
+func (c *backendGroupController) listEndpointNodes(svc *v1.Service, port lbcfapi.PortSelector) (sets.String, error) {
+	hosts := sets.NewString()
+	err := c.iterateReadyEndpoints(svc, port, func(ep endpointslice.Endpoint, port lbcfapi.PortSelector) {
+		if ep.NodeName != nil {
+			hosts.Insert(*ep.NodeName)
+		}
+	})
+	return hosts, err
+}
+
+// iterateReadyEndpoints calls handler with every ready endpoint of svc and the endpoint port serving wantedPort,
+// an endpoint is visited once even if it is in more than one EndpointSlice
+func (c *backendGroupController) iterateReadyEndpoints(svc *v1.Service, wantedPort lbcfapi.PortSelector,
+	handler func(ep endpointslice.Endpoint, port lbcfapi.PortSelector)) error {
+	if c.endpointSliceLister == nil {
+		return fmt.Errorf("%s is not served, service modes %s and %s are not supported",
+			endpointslice.SchemeGroupVersion, lbcfapi.ServiceModeEndpointPod, lbcfapi.ServiceModeEndpointNode)
+	}
+	svcPort := util.FindServicePort(svc, wantedPort)
+	if svcPort == nil {
+		klog.Infof("servicePort not found in svc %s/%s. looking for: %+v",
+			svc.Namespace, svc.Name, wantedPort)
+		return nil
+	}
+	slices, err := c.endpointSliceLister.ListByService(svc.Namespace, svc.Name)
+	if err != nil {
+		return err
+	}
+	visited := sets.NewString()
+	for _, slice := range slices {
+		if slice.AddressType != endpointslice.AddressTypeIPv4 && slice.AddressType != endpointslice.AddressTypeIPv6 {
+			continue
+		}
+		for _, port := range slice.Ports {
+			// endpoint ports are named after service ports
+			if port.Port == nil || port.GetName() != svcPort.Name || port.GetProtocol() != svcPort.Protocol {
+				continue
+			}
+			podPort := lbcfapi.PortSelector{
+				PortNumber: *port.Port,
+				Protocol:   string(port.GetProtocol()),
+			}
+			for _, ep := range slice.Endpoints {
+				if !ep.IsReady() || len(ep.Addresses) == 0 {
+					continue
+				}
+				key := fmt.Sprintf("%s/%d", ep.Addresses[0], podPort.PortNumber)
+				if visited.Has(key) {
+					continue
+				}
+				visited.Insert(key)
+				handler(ep, podPort)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *backendGroupController) expectedNodeBackends(group *lbcfapi.BackendGroup,
+	lb *lbcfapi.LoadBalancer) ([]*lbcfapi.BackendRecord, error) {
+	selector, err := util.NodeSelector(group.Spec.Nodes)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := c.nodeLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	var expectedRecords []*lbcfapi.BackendRecord
+	for _, node := range nodes {
+		if !util.NodeAvailable(node) {
+			continue
+		}
+		expectedRecords = append(expectedRecords, util.ConstructNodeBackendRecord(lb, group, node))
+	}
+	return expectedRecords, nil
+}
+
 func (c *backendGroupController) expectedStaticBackends(group *lbcfapi.BackendGroup,
 	lb *lbcfapi.LoadBalancer) ([]*lbcfapi.BackendRecord, error) {
 	var backends []*lbcfapi.BackendRecord
@@ -198,11 +363,16 @@ func (c *backendGroupController) expectedStaticBackends(group *lbcfapi.BackendGr
 
 func (c *backendGroupController) update(group *lbcfapi.BackendGroup,
 	lb *lbcfapi.LoadBalancer,
-	expectedBackends []*lbcfapi.BackendRecord) *util.SyncResult {
+	ports []lbcfapi.PortSelector,
+	expectedBackendsByPort [][]*lbcfapi.BackendRecord) *util.SyncResult {
 	existingRecords, err := c.listBackendRecords(group.Namespace, lb.Name, group.Name)
 	if err != nil {
 		return util.ErrorResult(err)
 	}
+	var expectedBackends []*lbcfapi.BackendRecord
+	for _, backends := range expectedBackendsByPort {
+		expectedBackends = append(expectedBackends, backends...)
+	}
 	needCreate, needUpdate, needDelete := util.CompareBackendRecords(expectedBackends, existingRecords)
 	var errs util.ErrorList
 	if err := util.IterateBackends(needDelete, c.deleteBackendRecord); err != nil {
@@ -226,10 +396,33 @@ func (c *backendGroupController) update(group *lbcfapi.BackendGroup,
 			curRegistered++
 		}
 	}
-	if group.Status.Backends != int32(curTotal) || group.Status.RegisteredBackends != curRegistered {
+	var portStatus []lbcfapi.BackendGroupPortStatus
+	if util.IsPortListUsed(group) {
+		registered := sets.NewString()
+		for _, r := range existingRecords {
+			if util.BackendRegistered(r) {
+				registered.Insert(r.Name)
+			}
+		}
+		for i, port := range ports {
+			status := lbcfapi.BackendGroupPortStatus{
+				Port:     port,
+				Backends: int32(len(expectedBackendsByPort[i])),
+			}
+			for _, r := range expectedBackendsByPort[i] {
+				if registered.Has(r.Name) {
+					status.RegisteredBackends++
+				}
+			}
+			portStatus = append(portStatus, status)
+		}
+	}
+	if group.Status.Backends != int32(curTotal) || group.Status.RegisteredBackends != curRegistered ||
+		!reflect.DeepEqual(group.Status.Ports, portStatus) {
 		group = group.DeepCopy()
 		group.Status.Backends = int32(curTotal)
 		group.Status.RegisteredBackends = curRegistered
+		group.Status.Ports = portStatus
 		if err := c.updateStatus(group, &group.Status); err != nil {
 			return util.ErrorResult(err)
 		}
@@ -252,11 +445,23 @@ func (c *backendGroupController) listRelatedBackendGroupsForPod(pod *v1.Pod) set
 	return groups
 }
 
+func (c *backendGroupController) listWeightedBackendGroupsForPod(pod *v1.Pod) sets.String {
+	filter := func(group *lbcfapi.BackendGroup) bool {
+		return util.IsWeightEnabled(group) && util.IsPodMatchBackendGroup(group, pod)
+	}
+	groups, err := c.listRelatedBackendGroups(pod.Namespace, filter)
+	if err != nil {
+		klog.Errorf("skip pod(%s/%s) update, list backendgroup failed: %v", pod.Namespace, pod.Name, err)
+		return nil
+	}
+	return groups
+}
+
 func (c *backendGroupController) listRelatedBackendGroupsForLB(lb *lbcfapi.LoadBalancer) sets.String {
 	filter := func(group *lbcfapi.BackendGroup) bool {
 		return util.IsLBMatchBackendGroup(group, lb)
 	}
-	groups, err := c.listRelatedBackendGroups(lb.Namespace, filter)
+	groups, err := c.listRelatedBackendGroups(metav1.NamespaceAll, filter)
 	if err != nil {
 		klog.Errorf("skip loadbalancer(%s/%s) add, list backendgroup failed: %v", lb.Namespace, lb.Name, err)
 		return nil
@@ -276,6 +481,30 @@ func (c *backendGroupController) listRelatedBackendGroupForSvc(svc *v1.Service)
 	return groups
 }
 
+func (c *backendGroupController) listRelatedBackendGroupsForNode(node *v1.Node) sets.String {
+	filter := func(group *lbcfapi.BackendGroup) bool {
+		return util.IsNodeMatchBackendGroup(group, node)
+	}
+	groups, err := c.listRelatedBackendGroups(metav1.NamespaceAll, filter)
+	if err != nil {
+		klog.Errorf("skip node(%s) add, list backendgroup failed: %v", node.Name, err)
+		return nil
+	}
+	return groups
+}
+
+func (c *backendGroupController) listRelatedBackendGroupsForEndpointSlice(slice *endpointslice.EndpointSlice) sets.String {
+	filter := func(group *lbcfapi.BackendGroup) bool {
+		return util.IsEndpointSliceMatchBackendGroup(group, slice)
+	}
+	groups, err := c.listRelatedBackendGroups(slice.Namespace, filter)
+	if err != nil {
+		klog.Errorf("skip endpointslice(%s/%s) add, list backendgroup failed: %v", slice.Namespace, slice.Name, err)
+		return nil
+	}
+	return groups
+}
+
 func (c *backendGroupController) listRelatedBackendGroups(namespace string,
 	filter func(group *lbcfapi.BackendGroup) bool) (sets.String, error) {
 	set := sets.NewString()
@@ -0,0 +1,157 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package lbcfcontroller
+
+import (
+	"testing"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/endpointslice"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestEndpointSliceLister(slices ...*endpointslice.EndpointSlice) endpointslice.Lister {
+	indexer := endpointslice.NewIndexer()
+	for _, slice := range slices {
+		indexer.Add(slice)
+	}
+	return endpointslice.NewLister(indexer)
+}
+
+func newTestEndpointSlice(name string, svcName string, portName string, port int32,
+	endpoints ...endpointslice.Endpoint) *endpointslice.EndpointSlice {
+	protocol := v1.ProtocolTCP
+	return &endpointslice.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{endpointslice.LabelServiceName: svcName},
+		},
+		AddressType: endpointslice.AddressTypeIPv4,
+		Endpoints:   endpoints,
+		Ports:       []endpointslice.EndpointPort{{Name: &portName, Protocol: &protocol, Port: &port}},
+	}
+}
+
+func newTestEndpoint(ip string, nodeName string, ready *bool) endpointslice.Endpoint {
+	return endpointslice.Endpoint{
+		Addresses:  []string{ip},
+		Conditions: endpointslice.EndpointConditions{Ready: ready},
+		NodeName:   &nodeName,
+		TargetRef: &v1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: "default",
+			Name:      "pod-" + ip,
+			UID:       types.UID("uid-" + ip),
+		},
+	}
+}
+
+func newTestEndpointService() *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{Name: "http", Port: 80, Protocol: v1.ProtocolTCP},
+				{Name: "metrics", Port: 9090, Protocol: v1.ProtocolTCP},
+			},
+		},
+	}
+}
+
+func TestListEndpointNodes(t *testing.T) {
+	ready, notReady := true, false
+	c := &backendGroupController{
+		endpointSliceLister: newTestEndpointSliceLister(
+			newTestEndpointSlice("svc-1", "svc", "http", 8080,
+				newTestEndpoint("10.0.0.1", "node-1", &ready),
+				newTestEndpoint("10.0.0.2", "node-2", &notReady),
+				// an unknown ready condition is interpreted as ready
+				newTestEndpoint("10.0.0.4", "node-4", nil)),
+			newTestEndpointSlice("svc-2", "svc", "metrics", 9090,
+				newTestEndpoint("10.0.0.3", "node-3", &ready)),
+			newTestEndpointSlice("other-1", "other", "http", 8080,
+				newTestEndpoint("10.0.0.5", "node-5", &ready)),
+		),
+	}
+	svc := newTestEndpointService()
+
+	hosts, err := c.listEndpointNodes(svc, lbcfapi.PortSelector{PortNumber: 80, Protocol: "TCP"})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if hosts.Len() != 2 || !hosts.Has("node-1") || !hosts.Has("node-4") {
+		t.Errorf("expect only nodes hosting ready endpoints of port 80, got %v", hosts.List())
+	}
+
+	missing := svc.DeepCopy()
+	missing.Name = "not-exist"
+	hosts, err = c.listEndpointNodes(missing, lbcfapi.PortSelector{PortNumber: 80, Protocol: "TCP"})
+	if err != nil || hosts.Len() != 0 {
+		t.Errorf("expect no node and no error for missing endpoints, got %v, %v", hosts.List(), err)
+	}
+}
+
+func TestExpectedEndpointPodBackends(t *testing.T) {
+	ready := true
+	// an endpoint may appear in more than one slice while slices are being updated
+	c := &backendGroupController{
+		endpointSliceLister: newTestEndpointSliceLister(
+			newTestEndpointSlice("svc-1", "svc", "http", 8080,
+				newTestEndpoint("10.0.0.1", "node-1", &ready),
+				newTestEndpoint("10.0.0.2", "node-1", &ready)),
+			newTestEndpointSlice("svc-2", "svc", "http", 8080,
+				newTestEndpoint("10.0.0.2", "node-1", &ready)),
+		),
+	}
+	lb := &lbcfapi.LoadBalancer{
+		ObjectMeta: metav1.ObjectMeta{Name: "lb", Namespace: "default"},
+		Spec:       lbcfapi.LoadBalancerSpec{LBDriver: "driver"},
+	}
+	group := &lbcfapi.BackendGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "default"},
+	}
+
+	records, err := c.expectedEndpointPodBackends(group, lb, newTestEndpointService(),
+		lbcfapi.PortSelector{PortNumber: 80, Protocol: "TCP"})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expect 2 records, got %d", len(records))
+	}
+	for _, record := range records {
+		port := record.Spec.PodBackendInfo.Port
+		if port.PortNumber != 8080 || port.Protocol != "TCP" {
+			t.Errorf("expect target port 8080/TCP, got %+v", port)
+		}
+	}
+	if records[0].Spec.PodBackendInfo.Name != "pod-10.0.0.1" || records[1].Spec.PodBackendInfo.Name != "pod-10.0.0.2" {
+		t.Errorf("unexpected pods: %s, %s", records[0].Spec.PodBackendInfo.Name, records[1].Spec.PodBackendInfo.Name)
+	}
+}
+
+func TestEndpointModesWithoutEndpointSlice(t *testing.T) {
+	c := &backendGroupController{}
+	if _, err := c.listEndpointNodes(newTestEndpointService(), lbcfapi.PortSelector{PortNumber: 80, Protocol: "TCP"}); err == nil {
+		t.Errorf("expect error if EndpointSlice is not served")
+	}
+}
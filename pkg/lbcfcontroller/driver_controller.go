@@ -18,26 +18,51 @@
 package lbcfcontroller
 
 import (
+	"fmt"
+	"sync"
+	"time"
+
 	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
 	lbcfclient "tkestack.io/lb-controlling-framework/pkg/client-go/clientset/versioned"
 	"tkestack.io/lb-controlling-framework/pkg/client-go/listers/lbcf.tke.cloud.tencent.com/v1beta1"
 	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
 
+	apicore "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog"
 )
 
-func newDriverController(client lbcfclient.Interface, lister v1beta1.LoadBalancerDriverLister) *driverController {
+func newDriverController(client lbcfclient.Interface,
+	lister v1beta1.LoadBalancerDriverLister,
+	recorder record.EventRecorder,
+	invoker util.WebhookInvoker) *driverController {
 	return &driverController{
-		lbcfClient: client,
-		lister:     lister,
+		lbcfClient:     client,
+		lister:         lister,
+		eventRecorder:  recorder,
+		webhookInvoker: invoker,
+		lastProbe:      new(sync.Map),
 	}
 }
 
+const (
+	// probeStatusRefreshInterval is the maximum interval between two status updates of an unchanged probe result
+	probeStatusRefreshInterval = 5 * time.Minute
+	// probeLatencyTolerance is the minimum change of probe latency that is written to status
+	probeLatencyTolerance = 100 * time.Millisecond
+)
+
 type driverController struct {
-	lbcfClient lbcfclient.Interface
-	lister     v1beta1.LoadBalancerDriverLister
+	lbcfClient     lbcfclient.Interface
+	lister         v1beta1.LoadBalancerDriverLister
+	eventRecorder  record.EventRecorder
+	webhookInvoker util.WebhookInvoker
+
+	// lastProbe stores the time of the last probe of each driver, because status is not updated by every probe
+	lastProbe *sync.Map
 }
 
 func (c *driverController) syncDriver(key string) *util.SyncResult {
@@ -47,6 +72,7 @@ func (c *driverController) syncDriver(key string) *util.SyncResult {
 	}
 	driver, err := c.lister.LoadBalancerDrivers(namespace).Get(name)
 	if errors.IsNotFound(err) {
+		c.lastProbe.Delete(key)
 		return util.FinishedResult()
 	} else if err != nil {
 		return util.ErrorResult(err)
@@ -58,6 +84,7 @@ func (c *driverController) syncDriver(key string) *util.SyncResult {
 
 	// create DriverConnector
 	if len(driver.Status.Conditions) == 0 {
+		driver = driver.DeepCopy()
 		driver.Status = lbcfapi.LoadBalancerDriverStatus{
 			Conditions: []lbcfapi.LoadBalancerDriverCondition{
 				{
@@ -67,11 +94,131 @@ func (c *driverController) syncDriver(key string) *util.SyncResult {
 				},
 			},
 		}
-		_, err := c.lbcfClient.LbcfV1beta1().LoadBalancerDrivers(namespace).UpdateStatus(driver)
+		driver, err = c.lbcfClient.LbcfV1beta1().LoadBalancerDrivers(namespace).UpdateStatus(driver)
 		if err != nil {
 			return util.ErrorResult(err)
 		}
-		return util.FinishedResult()
+	}
+
+	driver, err = c.syncCircuit(driver)
+	if err != nil {
+		return util.ErrorResult(err)
+	}
+
+	if driver.Spec.HealthCheck != nil {
+		return c.probe(driver)
 	}
 	return util.FinishedResult()
 }
+
+// syncCircuit records the circuit state of driver in condition CircuitOpen
+func (c *driverController) syncCircuit(driver *lbcfapi.LoadBalancerDriver) (*lbcfapi.LoadBalancerDriver, error) {
+	open := c.webhookInvoker.CircuitOpen(driver)
+	old := util.GetDriverCondition(&driver.Status, lbcfapi.DriverCircuitOpen)
+	if old == nil && !open {
+		return driver, nil
+	}
+	status := lbcfapi.ConditionFalse
+	if open {
+		status = lbcfapi.ConditionTrue
+	}
+	if old != nil && old.Status == status {
+		return driver, nil
+	}
+
+	condition := lbcfapi.LoadBalancerDriverCondition{
+		Type:               lbcfapi.DriverCircuitOpen,
+		Status:             status,
+		LastTransitionTime: v1.Now(),
+	}
+	if open {
+		condition.Reason = "TooManyFailures"
+		condition.Message = fmt.Sprintf("calls to driver are rejected for %s", util.CircuitOpenDuration.String())
+	}
+	driver = driver.DeepCopy()
+	util.AddDriverCondition(&driver.Status, condition)
+	updated, err := c.lbcfClient.LbcfV1beta1().LoadBalancerDrivers(driver.Namespace).UpdateStatus(driver)
+	if err != nil {
+		return nil, fmt.Errorf("update status of driver %s/%s failed: %v", driver.Namespace, driver.Name, err)
+	}
+	if open {
+		c.eventRecorder.Eventf(updated, apicore.EventTypeWarning, "CircuitOpened",
+			"too many webhook calls failed, calls are rejected for %s", util.CircuitOpenDuration.String())
+	} else {
+		c.eventRecorder.Eventf(updated, apicore.EventTypeNormal, "CircuitClosed", "webhook calls recovered")
+	}
+	return updated, nil
+}
+
+// probe checks the health of driver and records the result in condition Healthy
+func (c *driverController) probe(driver *lbcfapi.LoadBalancerDriver) *util.SyncResult {
+	period := util.GetDuration(driver.Spec.HealthCheck.Period, util.DefaultHealthCheckPeriod)
+	old := util.GetDriverCondition(&driver.Status, lbcfapi.DriverHealthy)
+	key := util.NamespacedNameKeyFunc(driver.Namespace, driver.Name)
+	// updating status triggers another sync, don't probe again before the period passes
+	var lastProbe time.Time
+	if t, ok := c.lastProbe.Load(key); ok {
+		lastProbe = t.(time.Time)
+	} else if old != nil {
+		lastProbe = old.LastProbeTime.Time
+	}
+	if elapsed := time.Since(lastProbe); elapsed < period {
+		return util.PeriodicResult(period - elapsed)
+	}
+
+	start := time.Now()
+	probeErr := c.webhookInvoker.ProbeDriver(driver)
+	latency := time.Since(start)
+	c.lastProbe.Store(key, start)
+
+	condition := lbcfapi.LoadBalancerDriverCondition{
+		Type:          lbcfapi.DriverHealthy,
+		Status:        lbcfapi.ConditionTrue,
+		LastProbeTime: v1.Now(),
+	}
+	if probeErr != nil {
+		klog.Warningf("probe driver %s/%s failed: %v", driver.Namespace, driver.Name, probeErr)
+		condition.Status = lbcfapi.ConditionFalse
+		condition.Reason = "ProbeFailed"
+		condition.Message = probeErr.Error()
+	}
+	condition.LastTransitionTime = condition.LastProbeTime
+	if old != nil && old.Status == condition.Status {
+		condition.LastTransitionTime = old.LastTransitionTime
+	}
+	if !needUpdateProbeStatus(old, &condition, driver.Status.LastProbeLatency, latency) {
+		return util.PeriodicResult(period)
+	}
+
+	driver = driver.DeepCopy()
+	util.AddDriverCondition(&driver.Status, condition)
+	driver.Status.LastProbeLatency = &lbcfapi.Duration{Duration: latency}
+	if _, err := c.lbcfClient.LbcfV1beta1().LoadBalancerDrivers(driver.Namespace).UpdateStatus(driver); err != nil {
+		return util.ErrorResult(fmt.Errorf("update status of driver %s/%s failed: %v", driver.Namespace, driver.Name, err))
+	}
+	return util.PeriodicResult(period)
+}
+
+// needUpdateProbeStatus returns false if the probe result is unchanged, the latency is within tolerance,
+// and status was updated in probeStatusRefreshInterval, so that drivers are not updated by every probe
+func needUpdateProbeStatus(old *lbcfapi.LoadBalancerDriverCondition, cur *lbcfapi.LoadBalancerDriverCondition,
+	oldLatency *lbcfapi.Duration, latency time.Duration) bool {
+	if old == nil || oldLatency == nil {
+		return true
+	}
+	if old.Status != cur.Status || old.Reason != cur.Reason || old.Message != cur.Message {
+		return true
+	}
+	if cur.LastProbeTime.Sub(old.LastProbeTime.Time) >= probeStatusRefreshInterval {
+		return true
+	}
+	tolerance := probeLatencyTolerance
+	if half := oldLatency.Duration / 2; half > tolerance {
+		tolerance = half
+	}
+	diff := latency - oldLatency.Duration
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff > tolerance
+}
@@ -0,0 +1,288 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package lbcfcontroller
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	lbcffake "tkestack.io/lb-controlling-framework/pkg/client-go/clientset/versioned/fake"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+type fakeProbeInvoker struct {
+	util.WebhookInvoker
+	probeErr    error
+	probes      int
+	circuitOpen bool
+}
+
+func (f *fakeProbeInvoker) ProbeDriver(driver *lbcfapi.LoadBalancerDriver) error {
+	f.probes++
+	return f.probeErr
+}
+
+func (f *fakeProbeInvoker) CircuitOpen(driver *lbcfapi.LoadBalancerDriver) bool {
+	return f.circuitOpen
+}
+
+func newTestProbeDriver(conditions ...lbcfapi.LoadBalancerDriverCondition) *lbcfapi.LoadBalancerDriver {
+	return &lbcfapi.LoadBalancerDriver{
+		ObjectMeta: metav1.ObjectMeta{Name: "lbcf-driver", Namespace: "kube-system"},
+		Spec: lbcfapi.LoadBalancerDriverSpec{
+			HealthCheck: &lbcfapi.DriverHealthCheck{Period: &lbcfapi.Duration{Duration: 10 * time.Second}},
+		},
+		Status: lbcfapi.LoadBalancerDriverStatus{
+			Conditions:       conditions,
+			LastProbeLatency: &lbcfapi.Duration{Duration: time.Millisecond},
+		},
+	}
+}
+
+func newTestHealthyCondition(status lbcfapi.ConditionStatus, probedAgo time.Duration) lbcfapi.LoadBalancerDriverCondition {
+	cond := lbcfapi.LoadBalancerDriverCondition{
+		Type:          lbcfapi.DriverHealthy,
+		Status:        status,
+		LastProbeTime: metav1.NewTime(time.Now().Add(-probedAgo)),
+	}
+	if status == lbcfapi.ConditionFalse {
+		cond.Reason = "ProbeFailed"
+		cond.Message = "driver down"
+	}
+	return cond
+}
+
+func newTestDriverController(driver *lbcfapi.LoadBalancerDriver,
+	invoker util.WebhookInvoker) (*driverController, *lbcffake.Clientset, *record.FakeRecorder) {
+	client := lbcffake.NewSimpleClientset(driver)
+	recorder := record.NewFakeRecorder(10)
+	return newDriverController(client, nil, recorder, invoker), client, recorder
+}
+
+func countStatusUpdates(client *lbcffake.Clientset) int {
+	n := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "update" && action.GetSubresource() == "status" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDriverProbe(t *testing.T) {
+	cases := []struct {
+		name         string
+		conditions   []lbcfapi.LoadBalancerDriverCondition
+		probeErr     error
+		expectUpdate bool
+		expectStatus lbcfapi.ConditionStatus
+	}{
+		{
+			name:         "first-probe",
+			expectUpdate: true,
+			expectStatus: lbcfapi.ConditionTrue,
+		},
+		{
+			name:       "unchanged",
+			conditions: []lbcfapi.LoadBalancerDriverCondition{newTestHealthyCondition(lbcfapi.ConditionTrue, time.Minute)},
+		},
+		{
+			name:         "became-unhealthy",
+			conditions:   []lbcfapi.LoadBalancerDriverCondition{newTestHealthyCondition(lbcfapi.ConditionTrue, time.Minute)},
+			probeErr:     fmt.Errorf("driver down"),
+			expectUpdate: true,
+			expectStatus: lbcfapi.ConditionFalse,
+		},
+		{
+			name:       "still-unhealthy",
+			conditions: []lbcfapi.LoadBalancerDriverCondition{newTestHealthyCondition(lbcfapi.ConditionFalse, time.Minute)},
+			probeErr:   fmt.Errorf("driver down"),
+		},
+		{
+			name:         "recovered",
+			conditions:   []lbcfapi.LoadBalancerDriverCondition{newTestHealthyCondition(lbcfapi.ConditionFalse, time.Minute)},
+			expectUpdate: true,
+			expectStatus: lbcfapi.ConditionTrue,
+		},
+		{
+			name:         "refresh-unchanged",
+			conditions:   []lbcfapi.LoadBalancerDriverCondition{newTestHealthyCondition(lbcfapi.ConditionTrue, probeStatusRefreshInterval)},
+			expectUpdate: true,
+			expectStatus: lbcfapi.ConditionTrue,
+		},
+	}
+	for _, c := range cases {
+		driver := newTestProbeDriver(c.conditions...)
+		invoker := &fakeProbeInvoker{probeErr: c.probeErr}
+		ctrl, client, _ := newTestDriverController(driver, invoker)
+
+		result := ctrl.probe(driver)
+		if !result.IsPeriodic() || result.GetNextRun() != 10*time.Second {
+			t.Errorf("case %s: expect next probe in 10s, got %+v", c.name, result)
+		}
+		if invoker.probes != 1 {
+			t.Errorf("case %s: expect 1 probe, got %d", c.name, invoker.probes)
+		}
+		updates := countStatusUpdates(client)
+		if !c.expectUpdate {
+			if updates != 0 {
+				t.Errorf("case %s: expect status not updated, got %d updates", c.name, updates)
+			}
+			continue
+		}
+		if updates != 1 {
+			t.Errorf("case %s: expect 1 status update, got %d", c.name, updates)
+			continue
+		}
+		updated, err := client.LbcfV1beta1().LoadBalancerDrivers(driver.Namespace).Get(driver.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %s: get driver failed: %v", c.name, err)
+		}
+		cond := util.GetDriverCondition(&updated.Status, lbcfapi.DriverHealthy)
+		if cond == nil || cond.Status != c.expectStatus {
+			t.Errorf("case %s: expect Healthy %s, got %+v", c.name, c.expectStatus, cond)
+		}
+	}
+}
+
+func TestDriverProbeWaitsForPeriod(t *testing.T) {
+	// status is not updated by an unchanged probe, so the time of last probe is remembered in memory
+	driver := newTestProbeDriver(newTestHealthyCondition(lbcfapi.ConditionTrue, time.Minute))
+	invoker := &fakeProbeInvoker{}
+	ctrl, _, _ := newTestDriverController(driver, invoker)
+
+	ctrl.probe(driver)
+	result := ctrl.probe(driver)
+	if invoker.probes != 1 {
+		t.Errorf("expect driver not to be probed again before the period passes, got %d probes", invoker.probes)
+	}
+	if !result.IsPeriodic() || result.GetNextRun() <= 0 || result.GetNextRun() > 10*time.Second {
+		t.Errorf("expect next probe within the period, got %s", result.GetNextRun())
+	}
+
+	// recently probed according to status, e.g. by the previous leader
+	driver = newTestProbeDriver(newTestHealthyCondition(lbcfapi.ConditionTrue, time.Second))
+	invoker = &fakeProbeInvoker{}
+	ctrl, _, _ = newTestDriverController(driver, invoker)
+	ctrl.probe(driver)
+	if invoker.probes != 0 {
+		t.Errorf("expect driver not to be probed, got %d probes", invoker.probes)
+	}
+}
+
+func TestNeedUpdateProbeStatus(t *testing.T) {
+	now := time.Now()
+	old := &lbcfapi.LoadBalancerDriverCondition{
+		Type:          lbcfapi.DriverHealthy,
+		Status:        lbcfapi.ConditionTrue,
+		LastProbeTime: metav1.NewTime(now.Add(-time.Minute)),
+	}
+	cases := []struct {
+		name       string
+		oldLatency *lbcfapi.Duration
+		latency    time.Duration
+		expect     bool
+	}{
+		{"no-latency", nil, time.Millisecond, true},
+		{"small-change", &lbcfapi.Duration{Duration: 10 * time.Millisecond}, 90 * time.Millisecond, false},
+		{"large-change", &lbcfapi.Duration{Duration: 10 * time.Millisecond}, 200 * time.Millisecond, true},
+		{"relative-tolerance", &lbcfapi.Duration{Duration: time.Second}, 1400 * time.Millisecond, false},
+		{"relative-change", &lbcfapi.Duration{Duration: time.Second}, 1600 * time.Millisecond, true},
+	}
+	for _, c := range cases {
+		cur := old.DeepCopy()
+		cur.LastProbeTime = metav1.NewTime(now)
+		if got := needUpdateProbeStatus(old, cur, c.oldLatency, c.latency); got != c.expect {
+			t.Errorf("case %s: expect %v, got %v", c.name, c.expect, got)
+		}
+	}
+}
+
+func TestDriverSyncCircuit(t *testing.T) {
+	circuitCond := func(status lbcfapi.ConditionStatus) lbcfapi.LoadBalancerDriverCondition {
+		return lbcfapi.LoadBalancerDriverCondition{Type: lbcfapi.DriverCircuitOpen, Status: status}
+	}
+	cases := []struct {
+		name         string
+		conditions   []lbcfapi.LoadBalancerDriverCondition
+		open         bool
+		expectStatus lbcfapi.ConditionStatus
+		expectEvent  string
+	}{
+		{
+			name: "closed-without-condition",
+		},
+		{
+			name:         "opened",
+			open:         true,
+			expectStatus: lbcfapi.ConditionTrue,
+			expectEvent:  "CircuitOpened",
+		},
+		{
+			name:       "still-open",
+			conditions: []lbcfapi.LoadBalancerDriverCondition{circuitCond(lbcfapi.ConditionTrue)},
+			open:       true,
+		},
+		{
+			name:         "closed",
+			conditions:   []lbcfapi.LoadBalancerDriverCondition{circuitCond(lbcfapi.ConditionTrue)},
+			expectStatus: lbcfapi.ConditionFalse,
+			expectEvent:  "CircuitClosed",
+		},
+		{
+			name:       "still-closed",
+			conditions: []lbcfapi.LoadBalancerDriverCondition{circuitCond(lbcfapi.ConditionFalse)},
+		},
+	}
+	for _, c := range cases {
+		driver := newTestProbeDriver(c.conditions...)
+		ctrl, client, recorder := newTestDriverController(driver, &fakeProbeInvoker{circuitOpen: c.open})
+
+		got, err := ctrl.syncCircuit(driver)
+		if err != nil {
+			t.Fatalf("case %s: expect no error, got %v", c.name, err)
+		}
+		if c.expectStatus == "" {
+			if countStatusUpdates(client) != 0 || got != driver {
+				t.Errorf("case %s: expect status not updated", c.name)
+			}
+			if len(recorder.Events) != 0 {
+				t.Errorf("case %s: expect no event, got %s", c.name, <-recorder.Events)
+			}
+			continue
+		}
+		cond := util.GetDriverCondition(&got.Status, lbcfapi.DriverCircuitOpen)
+		if countStatusUpdates(client) != 1 || cond == nil || cond.Status != c.expectStatus {
+			t.Errorf("case %s: expect CircuitOpen %s, got %+v", c.name, c.expectStatus, cond)
+		}
+		select {
+		case event := <-recorder.Events:
+			if !strings.Contains(event, c.expectEvent) {
+				t.Errorf("case %s: expect event %s, got %s", c.name, c.expectEvent, event)
+			}
+		default:
+			t.Errorf("case %s: expect event %s", c.name, c.expectEvent)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package endpointslice
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme)
+)
+
+func init() {
+	scheme.AddKnownTypes(SchemeGroupVersion, &EndpointSlice{}, &EndpointSliceList{})
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+}
+
+// NewForConfig creates a RESTClient for discovery.k8s.io/v1
+func NewForConfig(c *rest.Config) (rest.Interface, error) {
+	config := *c
+	config.GroupVersion = &SchemeGroupVersion
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: codecs}
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+	return rest.RESTClientFor(&config)
+}
+
+// IsServed returns true if the apiserver serves discovery.k8s.io/v1
+func IsServed(client discovery.DiscoveryInterface) (bool, error) {
+	groups, err := client.ServerGroups()
+	if err != nil {
+		return false, err
+	}
+	for _, group := range groups.Groups {
+		if group.Name != GroupName {
+			continue
+		}
+		for _, version := range group.Versions {
+			if version.Version == SchemeGroupVersion.Version {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// serviceIndex indexes EndpointSlices by namespace/name of the Service they belong to
+const serviceIndex = "service"
+
+func serviceIndexFunc(obj interface{}) ([]string, error) {
+	slice, ok := obj.(*EndpointSlice)
+	if !ok {
+		return nil, fmt.Errorf("expect *EndpointSlice, got %T", obj)
+	}
+	svcName, ok := slice.Labels[LabelServiceName]
+	if !ok {
+		return nil, nil
+	}
+	return []string{slice.Namespace + "/" + svcName}, nil
+}
+
+func indexers() cache.Indexers {
+	return cache.Indexers{
+		cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		serviceIndex:         serviceIndexFunc,
+	}
+}
+
+// NewIndexer returns an Indexer that can be used by Lister
+func NewIndexer() cache.Indexer {
+	return cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers())
+}
+
+// Informer provides access to a shared informer and lister for EndpointSlices
+type Informer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() Lister
+}
+
+// NewInformer creates an Informer that watches EndpointSlices in all namespaces
+func NewInformer(client rest.Interface, resyncPeriod time.Duration) Informer {
+	lw := cache.NewListWatchFromClient(client, "endpointslices", metav1.NamespaceAll, fields.Everything())
+	return &endpointSliceInformer{
+		informer: cache.NewSharedIndexInformer(lw, &EndpointSlice{}, resyncPeriod, indexers()),
+	}
+}
+
+type endpointSliceInformer struct {
+	informer cache.SharedIndexInformer
+}
+
+func (i *endpointSliceInformer) Informer() cache.SharedIndexInformer {
+	return i.informer
+}
+
+func (i *endpointSliceInformer) Lister() Lister {
+	return NewLister(i.informer.GetIndexer())
+}
+
+// Lister lists EndpointSlices from the informer cache
+type Lister interface {
+	// ListByService returns EndpointSlices that belong to the Service namespace/name
+	ListByService(namespace, name string) ([]*EndpointSlice, error)
+}
+
+// NewLister returns a Lister backed by indexer, the indexer must be created by NewIndexer or NewInformer
+func NewLister(indexer cache.Indexer) Lister {
+	return &endpointSliceLister{indexer: indexer}
+}
+
+type endpointSliceLister struct {
+	indexer cache.Indexer
+}
+
+func (l *endpointSliceLister) ListByService(namespace, name string) ([]*EndpointSlice, error) {
+	objs, err := l.indexer.ByIndex(serviceIndex, namespace+"/"+name)
+	if err != nil {
+		return nil, err
+	}
+	var slices []*EndpointSlice
+	for _, obj := range objs {
+		slices = append(slices, obj.(*EndpointSlice))
+	}
+	return slices, nil
+}
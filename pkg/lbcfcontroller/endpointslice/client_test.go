@@ -0,0 +1,119 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package endpointslice
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDecodeEndpointSlice(t *testing.T) {
+	raw := []byte(`{
+  "apiVersion": "discovery.k8s.io/v1",
+  "kind": "EndpointSlice",
+  "metadata": {"name": "svc-abcde", "namespace": "default", "labels": {"kubernetes.io/service-name": "svc"}},
+  "addressType": "IPv4",
+  "endpoints": [
+    {"addresses": ["10.0.0.1"], "conditions": {"ready": false}, "nodeName": "node-1",
+     "targetRef": {"kind": "Pod", "namespace": "default", "name": "pod-1"}},
+    {"addresses": ["10.0.0.2"], "conditions": {}}
+  ],
+  "ports": [{"name": "http", "port": 8080}]
+}`)
+	obj, err := runtime.Decode(codecs.UniversalDecoder(SchemeGroupVersion), raw)
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	slice, ok := obj.(*EndpointSlice)
+	if !ok {
+		t.Fatalf("expect *EndpointSlice, got %T", obj)
+	}
+	if slice.AddressType != AddressTypeIPv4 || len(slice.Endpoints) != 2 || len(slice.Ports) != 1 {
+		t.Fatalf("unexpected slice: %+v", slice)
+	}
+	if slice.Endpoints[0].IsReady() || *slice.Endpoints[0].NodeName != "node-1" || slice.Endpoints[0].TargetRef.Name != "pod-1" {
+		t.Errorf("unexpected endpoint: %+v", slice.Endpoints[0])
+	}
+	if !slice.Endpoints[1].IsReady() {
+		t.Errorf("expect endpoint with unknown ready condition to be ready")
+	}
+	if slice.Ports[0].GetName() != "http" || slice.Ports[0].GetProtocol() != "TCP" || *slice.Ports[0].Port != 8080 {
+		t.Errorf("unexpected port: %+v", slice.Ports[0])
+	}
+}
+
+func TestListByService(t *testing.T) {
+	indexer := NewIndexer()
+	for _, slice := range []*EndpointSlice{
+		{ObjectMeta: v1.ObjectMeta{Name: "a-1", Namespace: "default", Labels: map[string]string{LabelServiceName: "a"}}},
+		{ObjectMeta: v1.ObjectMeta{Name: "a-2", Namespace: "default", Labels: map[string]string{LabelServiceName: "a"}}},
+		{ObjectMeta: v1.ObjectMeta{Name: "a-3", Namespace: "other", Labels: map[string]string{LabelServiceName: "a"}}},
+		{ObjectMeta: v1.ObjectMeta{Name: "b-1", Namespace: "default", Labels: map[string]string{LabelServiceName: "b"}}},
+		{ObjectMeta: v1.ObjectMeta{Name: "unmanaged", Namespace: "default"}},
+	} {
+		indexer.Add(slice)
+	}
+	slices, err := NewLister(indexer).ListByService("default", "a")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if len(slices) != 2 {
+		t.Fatalf("expect 2 slices, got %d", len(slices))
+	}
+	for _, slice := range slices {
+		if slice.Name != "a-1" && slice.Name != "a-2" {
+			t.Errorf("unexpected slice %s", slice.Name)
+		}
+	}
+}
+
+func TestIsServed(t *testing.T) {
+	cases := []struct {
+		name      string
+		resources []*v1.APIResourceList
+		expect    bool
+	}{
+		{
+			name:      "v1",
+			resources: []*v1.APIResourceList{{GroupVersion: "discovery.k8s.io/v1"}},
+			expect:    true,
+		},
+		{
+			name:      "v1beta1-only",
+			resources: []*v1.APIResourceList{{GroupVersion: "discovery.k8s.io/v1beta1"}},
+		},
+		{
+			name:      "not-served",
+			resources: []*v1.APIResourceList{{GroupVersion: "v1"}},
+		},
+	}
+	for _, c := range cases {
+		client := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: c.resources}}
+		served, err := IsServed(client)
+		if err != nil {
+			t.Fatalf("case %s: expect no error, got %v", c.name, err)
+		}
+		if served != c.expect {
+			t.Errorf("case %s: expect %v, got %v", c.name, c.expect, served)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package endpointslice provides the discovery.k8s.io/v1 EndpointSlice types, client and informer,
+// which are not provided by the version of client-go we are using.
+// Only fields used by lbcf-controller are defined, other fields are dropped when decoding.
+package endpointslice
+
+import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GroupName is the group name of EndpointSlice
+const GroupName = "discovery.k8s.io"
+
+// SchemeGroupVersion is the group version of EndpointSlice used by lbcf-controller
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1"}
+
+const (
+	// LabelServiceName is set on EndpointSlices to the name of the Service they belong to
+	LabelServiceName = "kubernetes.io/service-name"
+
+	// AddressTypeIPv4 represents an IPv4 address
+	AddressTypeIPv4 = "IPv4"
+	// AddressTypeIPv6 represents an IPv6 address
+	AddressTypeIPv6 = "IPv6"
+)
+
+// EndpointSlice represents a subset of the endpoints that implement a service
+type EndpointSlice struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	AddressType string         `json:"addressType"`
+	Endpoints   []Endpoint     `json:"endpoints"`
+	Ports       []EndpointPort `json:"ports"`
+}
+
+// Endpoint represents a single logical "backend" implementing a service
+type Endpoint struct {
+	Addresses  []string            `json:"addresses"`
+	Conditions EndpointConditions  `json:"conditions,omitempty"`
+	NodeName   *string             `json:"nodeName,omitempty"`
+	TargetRef  *v1.ObjectReference `json:"targetRef,omitempty"`
+}
+
+// IsReady returns true if the endpoint is ready, an unknown state is interpreted as ready
+func (e *Endpoint) IsReady() bool {
+	return e.Conditions.Ready == nil || *e.Conditions.Ready
+}
+
+// EndpointConditions represents the current condition of an endpoint
+type EndpointConditions struct {
+	Ready *bool `json:"ready,omitempty"`
+}
+
+// EndpointPort represents a Port used by an EndpointSlice
+type EndpointPort struct {
+	Name     *string      `json:"name,omitempty"`
+	Protocol *v1.Protocol `json:"protocol,omitempty"`
+	Port     *int32       `json:"port,omitempty"`
+}
+
+// GetName returns the name of the port, empty if not set
+func (p *EndpointPort) GetName() string {
+	if p.Name == nil {
+		return ""
+	}
+	return *p.Name
+}
+
+// GetProtocol returns the protocol of the port, TCP if not set
+func (p *EndpointPort) GetProtocol() v1.Protocol {
+	if p.Protocol == nil {
+		return v1.ProtocolTCP
+	}
+	return *p.Protocol
+}
+
+// EndpointSliceList represents a list of EndpointSlices
+type EndpointSliceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []EndpointSlice `json:"items"`
+}
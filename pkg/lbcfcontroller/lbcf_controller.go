@@ -18,11 +18,16 @@
 package lbcfcontroller
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"tkestack.io/lb-controlling-framework/cmd/lbcf-controller/app/context"
 	"tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/endpointslice"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/metrics"
 	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
 
 	"k8s.io/api/core/v1"
@@ -33,10 +38,20 @@ import (
 	"k8s.io/kubernetes/pkg/controller"
 )
 
+// names of controllers, used in metrics
+const (
+	driverCtrlName       = "driver"
+	loadBalancerCtrlName = "loadBalancer"
+	backendGroupCtrlName = "backendGroup"
+	backendCtrlName      = "backend"
+	podCtrlName          = "pod"
+)
+
 // NewController creates a new LBCF-controller
 func NewController(ctx *context.Context) *Controller {
 	c := &Controller{
-		context: ctx,
+		context:     ctx,
+		syncTracker: util.NewSyncTracker(),
 		driverQueue: util.NewConditionalDelayingQueue(nil,
 			ctx.Cfg.MinRetryDelay, ctx.Cfg.RetryDelayStep, ctx.Cfg.MaxRetryDelay),
 		loadBalancerQueue: util.NewConditionalDelayingQueue(util.QueueFilterForLB(ctx.LBInformer.Lister()),
@@ -45,21 +60,42 @@ func NewController(ctx *context.Context) *Controller {
 			ctx.Cfg.MinRetryDelay, ctx.Cfg.RetryDelayStep, ctx.Cfg.MaxRetryDelay),
 		backendQueue: util.NewConditionalDelayingQueue(util.QueueFilterForBackend(ctx.BRInformer.Lister()),
 			ctx.Cfg.MinRetryDelay, ctx.Cfg.RetryDelayStep, ctx.Cfg.MaxRetryDelay),
+		podQueue: util.NewConditionalDelayingQueue(nil,
+			ctx.Cfg.MinRetryDelay, ctx.Cfg.RetryDelayStep, ctx.Cfg.MaxRetryDelay),
 	}
 
-	c.driverCtrl = newDriverController(c.context.LbcfClient, c.context.LBDriverInformer.Lister())
+	inFlightLimiter := util.NewInFlightLimiter(ctx.Cfg.MaxInFlightPerDriver)
+	// all controllers share the same invoker so that circuits of drivers are shared
+	webhookInvoker := util.NewWebhookInvoker(ctx.SecretGetter)
+	webhookInvoker.OnCircuitChange(func(driverKey string) {
+		c.driverQueue.Add(driverKey)
+	})
+	c.podDrainer = newPodDrainer(ctx.K8sClient, ctx.PodInformer.Lister(), ctx.BRInformer.Lister(), ctx.EventRecorder)
+	c.driverCtrl = newDriverController(c.context.LbcfClient, c.context.LBDriverInformer.Lister(),
+		ctx.EventRecorder, webhookInvoker)
 	c.lbCtrl = newLoadBalancerController(c.context.LbcfClient,
-		c.context.LBInformer.Lister(), ctx.LBDriverInformer.Lister(), ctx.EventRecorder, util.NewWebhookInvoker())
+		c.context.LBInformer.Lister(), ctx.LBDriverInformer.Lister(), ctx.EventRecorder, webhookInvoker, inFlightLimiter)
 	c.backendCtrl = newBackendController(
 		c.context.LbcfClient,
+		c.context.K8sClient,
 		c.context.BRInformer.Lister(),
 		ctx.LBDriverInformer.Lister(),
 		c.context.PodInformer.Lister(),
 		c.context.SvcInformer.Lister(),
 		c.context.NodeInformer.Lister(),
 		c.context.EventRecorder,
-		util.NewWebhookInvoker(),
+		webhookInvoker,
+		inFlightLimiter,
+		c.podDrainer,
+		ctx.Cfg.BackendBatchWindow,
 	)
+	c.backendCtrl.batcher.setOnDone(func(key string) {
+		c.backendQueue.Add(key)
+	})
+	var endpointSliceLister endpointslice.Lister
+	if c.context.EndpointSliceInformer != nil {
+		endpointSliceLister = c.context.EndpointSliceInformer.Lister()
+	}
 	c.backendGroupCtrl = newBackendGroupController(
 		c.context.LbcfClient,
 		c.context.LBInformer.Lister(),
@@ -68,8 +104,19 @@ func NewController(ctx *context.Context) *Controller {
 		c.context.PodInformer.Lister(),
 		c.context.SvcInformer.Lister(),
 		c.context.NodeInformer.Lister(),
+		endpointSliceLister,
+		c.podDrainer,
 	)
 
+	metrics.RegisterQueues(map[string]metrics.Queue{
+		driverCtrlName:       c.driverQueue,
+		loadBalancerCtrlName: c.loadBalancerQueue,
+		backendGroupCtrlName: c.backendGroupQueue,
+		backendCtrlName:      c.backendQueue,
+		podCtrlName:          c.podQueue,
+	})
+	metrics.RegisterBackendGroups(c.context.BGInformer.Lister())
+
 	// enqueue backendgroup
 	c.context.PodInformer.Informer().AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addPod,
@@ -84,6 +131,22 @@ func NewController(ctx *context.Context) *Controller {
 		DeleteFunc: c.deleteService,
 	}, c.context.Cfg.InformerResyncPeriod)
 
+	// enqueue backendgroup
+	c.context.NodeInformer.Informer().AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addNode,
+		UpdateFunc: c.updateNode,
+		DeleteFunc: c.deleteNode,
+	}, c.context.Cfg.InformerResyncPeriod)
+
+	// enqueue backendgroup
+	if c.context.EndpointSliceInformer != nil {
+		c.context.EndpointSliceInformer.Informer().AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addEndpointSlice,
+			UpdateFunc: c.updateEndpointSlice,
+			DeleteFunc: c.deleteEndpointSlice,
+		}, c.context.Cfg.InformerResyncPeriod)
+	}
+
 	// control loadBalancer lifecycle
 	c.context.LBInformer.Informer().AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addLoadBalancer,
@@ -122,11 +185,16 @@ type Controller struct {
 	lbCtrl           *loadBalancerController
 	backendCtrl      *backendController
 	backendGroupCtrl *backendGroupController
+	podDrainer       *podDrainer
 
 	driverQueue       util.ConditionalRateLimitingInterface
 	loadBalancerQueue util.ConditionalRateLimitingInterface
 	backendGroupQueue util.ConditionalRateLimitingInterface
 	backendQueue      util.ConditionalRateLimitingInterface
+	podQueue          util.ConditionalRateLimitingInterface
+
+	syncTracker    *util.SyncTracker
+	workersStarted int32
 }
 
 // Start starts controller in a new goroutine
@@ -136,10 +204,35 @@ func (c *Controller) Start() {
 
 func (c *Controller) run() {
 	c.context.WaitForCacheSync()
-	go wait.Until(c.lbWorker, time.Second, wait.NeverStop)
-	go wait.Until(c.driverWorker, time.Second, wait.NeverStop)
-	go wait.Until(c.backendGroupWorker, time.Second, wait.NeverStop)
-	go wait.Until(c.backendWorker, time.Second, wait.NeverStop)
+	startWorkers(c.lbWorker, c.context.Cfg.LBWorkers)
+	startWorkers(c.driverWorker, c.context.Cfg.DriverWorkers)
+	startWorkers(c.backendGroupWorker, c.context.Cfg.BackendGroupWorkers)
+	startWorkers(c.backendWorker, c.context.Cfg.BackendWorkers)
+	// releasing pods in drain mode is part of controlling BackendGroups
+	startWorkers(c.podWorker, c.context.Cfg.BackendGroupWorkers)
+	atomic.StoreInt32(&c.workersStarted, 1)
+}
+
+// WorkersStarted returns true if workers are started
+func (c *Controller) WorkersStarted() bool {
+	return atomic.LoadInt32(&c.workersStarted) == 1
+}
+
+// CheckWorkers returns an error if any worker has been syncing a key for longer than threshold
+func (c *Controller) CheckWorkers(threshold time.Duration) error {
+	if stuck := c.syncTracker.Stuck(threshold); len(stuck) > 0 {
+		return fmt.Errorf("workers stuck longer than %s: %s", threshold, strings.Join(stuck, ", "))
+	}
+	return nil
+}
+
+func startWorkers(worker func(), n int) {
+	if n < 1 {
+		n = 1
+	}
+	for i := 0; i < n; i++ {
+		go wait.Until(worker, time.Second, wait.NeverStop)
+	}
 }
 
 func (c *Controller) enqueue(obj interface{}, queue util.ConditionalRateLimitingInterface) {
@@ -156,62 +249,81 @@ func (c *Controller) enqueue(obj interface{}, queue util.ConditionalRateLimiting
 }
 
 func (c *Controller) lbWorker() {
-	for c.processNextItem(c.loadBalancerQueue, c.lbCtrl.syncLB) {
+	for c.processNextItem(loadBalancerCtrlName, c.loadBalancerQueue, c.lbCtrl.syncLB) {
 	}
 }
 
 func (c *Controller) driverWorker() {
-	for c.processNextItem(c.driverQueue, c.driverCtrl.syncDriver) {
+	for c.processNextItem(driverCtrlName, c.driverQueue, c.driverCtrl.syncDriver) {
 	}
 }
 
 func (c *Controller) backendGroupWorker() {
-	for c.processNextItem(c.backendGroupQueue, c.backendGroupCtrl.syncBackendGroup) {
+	for c.processNextItem(backendGroupCtrlName, c.backendGroupQueue, c.backendGroupCtrl.syncBackendGroup) {
 	}
 }
 
 func (c *Controller) backendWorker() {
-	for c.processNextItem(c.backendQueue, c.backendCtrl.syncBackendRecord) {
+	for c.processNextItem(backendCtrlName, c.backendQueue, c.backendCtrl.syncBackendRecord) {
 	}
 }
 
-func (c *Controller) processNextItem(queue util.ConditionalRateLimitingInterface,
+func (c *Controller) podWorker() {
+	for c.processNextItem(podCtrlName, c.podQueue, c.podDrainer.syncPod) {
+	}
+}
+
+func (c *Controller) processNextItem(name string, queue util.ConditionalRateLimitingInterface,
 	syncFunc func(string) *util.SyncResult) bool {
 	key, quit := queue.Get()
 	if quit {
 		return false
 	}
 
-	go func() {
-		defer queue.Done(key)
-
-		klog.V(3).Infof("sync start, key %s", key)
-		startTime := time.Now()
-		result := syncFunc(key.(string))
-
-		if !result.IsFailed() {
-			queue.Forget(key)
-		}
-
-		if result.IsFailed() {
-			klog.Infof("Failed key %s, reason: %v", key, result.GetFailReason())
-			queue.AddAfterMinimumDelay(key, result.GetNextRun())
-		} else if result.IsRunning() {
-			klog.Infof("Async key %s", key)
-			queue.AddAfterMinimumDelay(key, result.GetNextRun())
-		} else if result.IsPeriodic() {
-			klog.Infof("Periodic key %s", key)
-			queue.AddAfterFiltered(key, result.GetNextRun())
-		}
-
-		elapsed := time.Now().Sub(startTime)
-		klog.V(3).Infof("sync finished, key %s, took %s", key, elapsed.String())
-	}()
+	defer queue.Done(key)
+	defer c.syncTracker.Start(name, key.(string))()
+
+	klog.V(3).Infof("sync start, key %s", key)
+	startTime := time.Now()
+	result := syncFunc(key.(string))
+
+	// throttled keys are not forgotten, so that the delay grows with the rate limiter
+	if !result.IsFailed() && !result.IsThrottled() {
+		queue.Forget(key)
+	}
+
+	if result.IsFailed() {
+		klog.Infof("Failed key %s, reason: %v", key, result.GetFailReason())
+		queue.AddAfterMinimumDelay(key, result.GetNextRun())
+		metrics.SyncFinished(name, metrics.SyncResultFailed)
+		metrics.QueueRetried(name)
+	} else if result.IsRunning() {
+		klog.Infof("Async key %s", key)
+		queue.AddAfterMinimumDelay(key, result.GetNextRun())
+		metrics.SyncFinished(name, metrics.SyncResultRunning)
+	} else if result.IsThrottled() {
+		klog.V(3).Infof("Throttled key %s", key)
+		// jitter prevents keys rejected at the same time from retrying at the same time
+		queue.AddAfterMinimumDelay(key, wait.Jitter(util.InFlightRetryDelay, 1.0))
+		metrics.SyncFinished(name, metrics.SyncResultThrottled)
+	} else if result.IsPeriodic() {
+		klog.Infof("Periodic key %s", key)
+		queue.AddAfterFiltered(key, result.GetNextRun())
+		metrics.SyncFinished(name, metrics.SyncResultPeriodic)
+	} else {
+		metrics.SyncFinished(name, metrics.SyncResultFinished)
+	}
+
+	elapsed := time.Now().Sub(startTime)
+	klog.V(3).Infof("sync finished, key %s, took %s", key, elapsed.String())
 	return true
 }
 
 func (c *Controller) addPod(obj interface{}) {
 	pod := obj.(*v1.Pod)
+	if needRelease(pod) {
+		c.enqueue(pod, c.podQueue)
+	}
 	for key := range c.backendGroupCtrl.listRelatedBackendGroupsForPod(pod) {
 		c.enqueue(key, c.backendGroupQueue)
 	}
@@ -223,6 +335,10 @@ func (c *Controller) updatePod(old, cur interface{}) {
 	if oldPod.ResourceVersion == curPod.ResourceVersion {
 		return
 	}
+	if needRelease(curPod) {
+		// the pod may be no longer selected by any BackendGroup in drain mode, e.g., the BackendGroup is deleted
+		c.enqueue(curPod, c.podQueue)
+	}
 
 	labelChanged := !reflect.DeepEqual(oldPod.Labels, curPod.Labels)
 	statusChanged := util.PodAvailable(oldPod) != util.PodAvailable(curPod)
@@ -234,6 +350,14 @@ func (c *Controller) updatePod(old, cur interface{}) {
 		for key := range groups {
 			c.enqueue(key, c.backendGroupQueue)
 		}
+		return
+	}
+
+	// weight of pod backends may be changed
+	if !reflect.DeepEqual(oldPod.Annotations, curPod.Annotations) {
+		for key := range c.backendGroupCtrl.listWeightedBackendGroupsForPod(curPod) {
+			c.enqueue(key, c.backendGroupQueue)
+		}
 	}
 }
 
@@ -298,6 +422,86 @@ func (c *Controller) deleteService(obj interface{}) {
 	c.addService(svc)
 }
 
+func (c *Controller) addNode(obj interface{}) {
+	node := obj.(*v1.Node)
+	for key := range c.backendGroupCtrl.listRelatedBackendGroupsForNode(node) {
+		c.enqueue(key, c.backendGroupQueue)
+	}
+}
+
+func (c *Controller) updateNode(old, cur interface{}) {
+	oldNode := old.(*v1.Node)
+	curNode := cur.(*v1.Node)
+	if oldNode.ResourceVersion == curNode.ResourceVersion {
+		return
+	}
+	labelChanged := !reflect.DeepEqual(oldNode.Labels, curNode.Labels)
+	statusChanged := util.NodeAvailable(oldNode) != util.NodeAvailable(curNode)
+	// IPs of node are part of the name of node BackendRecords, see util.MakeNodeBackendName
+	addrChanged := !reflect.DeepEqual(oldNode.Status.Addresses, curNode.Status.Addresses)
+	if !labelChanged && !statusChanged && !addrChanged {
+		return
+	}
+	oldGroups := c.backendGroupCtrl.listRelatedBackendGroupsForNode(oldNode)
+	groups := c.backendGroupCtrl.listRelatedBackendGroupsForNode(curNode)
+	for key := range groups.Union(oldGroups) {
+		c.enqueue(key, c.backendGroupQueue)
+	}
+}
+
+func (c *Controller) deleteNode(obj interface{}) {
+	if _, ok := obj.(*v1.Node); ok {
+		c.addNode(obj)
+		return
+	}
+	tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+	if !ok {
+		klog.Errorf("Couldn't get object from tombstone %#v", obj)
+		return
+	}
+	node, ok := tombstone.Obj.(*v1.Node)
+	if !ok {
+		klog.Errorf("Tombstone contained object that is not a Node: %#v", obj)
+		return
+	}
+	c.addNode(node)
+}
+
+func (c *Controller) addEndpointSlice(obj interface{}) {
+	slice := obj.(*endpointslice.EndpointSlice)
+	for key := range c.backendGroupCtrl.listRelatedBackendGroupsForEndpointSlice(slice) {
+		c.enqueue(key, c.backendGroupQueue)
+	}
+}
+
+func (c *Controller) updateEndpointSlice(old, cur interface{}) {
+	oldSlice := old.(*endpointslice.EndpointSlice)
+	curSlice := cur.(*endpointslice.EndpointSlice)
+	if oldSlice.ResourceVersion == curSlice.ResourceVersion ||
+		(reflect.DeepEqual(oldSlice.Endpoints, curSlice.Endpoints) && reflect.DeepEqual(oldSlice.Ports, curSlice.Ports)) {
+		return
+	}
+	c.addEndpointSlice(curSlice)
+}
+
+func (c *Controller) deleteEndpointSlice(obj interface{}) {
+	if _, ok := obj.(*endpointslice.EndpointSlice); ok {
+		c.addEndpointSlice(obj)
+		return
+	}
+	tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+	if !ok {
+		klog.Errorf("Couldn't get object from tombstone %#v", obj)
+		return
+	}
+	slice, ok := tombstone.Obj.(*endpointslice.EndpointSlice)
+	if !ok {
+		klog.Errorf("Tombstone contained object that is not an EndpointSlice: %#v", obj)
+		return
+	}
+	c.addEndpointSlice(slice)
+}
+
 func (c *Controller) addBackendGroup(obj interface{}) {
 	c.enqueue(obj, c.backendGroupQueue)
 }
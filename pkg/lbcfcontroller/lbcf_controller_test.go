@@ -0,0 +1,127 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package lbcfcontroller
+
+import (
+	"testing"
+	"time"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	lbcflister "tkestack.io/lb-controlling-framework/pkg/client-go/listers/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpdateNodeEnqueuesOnAddressChange(t *testing.T) {
+	group := &lbcfapi.BackendGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "default"},
+		Spec: lbcfapi.BackendGroupSpec{
+			Nodes: &lbcfapi.NodeBackend{Port: lbcfapi.PortSelector{PortNumber: 80, Protocol: "TCP"}},
+		},
+	}
+	c := &Controller{
+		backendGroupCtrl: &backendGroupController{
+			bgLister: lbcflister.NewBackendGroupLister(newTestIndexer(group)),
+		},
+		backendGroupQueue: util.NewConditionalDelayingQueue(nil, time.Second, time.Second, time.Second),
+	}
+	defer c.backendGroupQueue.ShutDown()
+
+	ready := []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}
+	oldNode := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", ResourceVersion: "1"},
+		Status: v1.NodeStatus{
+			Conditions: ready,
+			Addresses:  []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: "10.0.0.1"}},
+		},
+	}
+
+	heartbeat := oldNode.DeepCopy()
+	heartbeat.ResourceVersion = "2"
+	c.updateNode(oldNode, heartbeat)
+	if c.backendGroupQueue.Len() != 0 {
+		t.Fatalf("expect node heartbeat not to enqueue BackendGroups")
+	}
+
+	curNode := oldNode.DeepCopy()
+	curNode.ResourceVersion = "3"
+	curNode.Status.Addresses[0].Address = "10.0.0.2"
+	c.updateNode(oldNode, curNode)
+	if c.backendGroupQueue.Len() != 1 {
+		t.Fatalf("expect BackendGroup to be enqueued after InternalIP changed, queue length %d",
+			c.backendGroupQueue.Len())
+	}
+	if key, _ := c.backendGroupQueue.Get(); key != "default/group" {
+		t.Errorf("unexpected key %v", key)
+	}
+}
+
+// recordingQueue records the delays and Forget calls of a ConditionalRateLimitingInterface
+type recordingQueue struct {
+	util.ConditionalRateLimitingInterface
+	forgotten int
+	delays    []time.Duration
+}
+
+func (q *recordingQueue) AddAfterMinimumDelay(item interface{}, minDelay time.Duration) {
+	q.delays = append(q.delays, minDelay)
+}
+
+func (q *recordingQueue) Forget(item interface{}) {
+	q.forgotten++
+}
+
+func TestProcessNextItemThrottled(t *testing.T) {
+	queue := &recordingQueue{
+		ConditionalRateLimitingInterface: util.NewConditionalDelayingQueue(nil, time.Second, time.Second, time.Second),
+	}
+	defer queue.ShutDown()
+	c := &Controller{syncTracker: util.NewSyncTracker()}
+
+	for i := 0; i < 10; i++ {
+		queue.Add("default/record")
+		c.processNextItem(backendCtrlName, queue, func(string) *util.SyncResult {
+			return util.ThrottledResult()
+		})
+	}
+	if queue.forgotten != 0 {
+		t.Errorf("expect throttled key not to be forgotten, so that the delay grows")
+	}
+	jittered := false
+	for _, delay := range queue.delays {
+		if delay < util.InFlightRetryDelay || delay > 2*util.InFlightRetryDelay {
+			t.Errorf("expect delay between %s and %s, got %s", util.InFlightRetryDelay, 2*util.InFlightRetryDelay, delay)
+		}
+		if delay != queue.delays[0] {
+			jittered = true
+		}
+	}
+	if len(queue.delays) != 10 || !jittered {
+		t.Errorf("expect 10 jittered delays, got %v", queue.delays)
+	}
+
+	queue.Add("default/record")
+	c.processNextItem(backendCtrlName, queue, func(string) *util.SyncResult {
+		return util.FinishedResult()
+	})
+	if queue.forgotten != 1 {
+		t.Errorf("expect key to be forgotten once finished")
+	}
+}
@@ -37,13 +37,15 @@ import (
 func newLoadBalancerController(client lbcfclient.Interface,
 	lbLister v1beta1.LoadBalancerLister,
 	driverLister v1beta1.LoadBalancerDriverLister,
-	recorder record.EventRecorder, invoker util.WebhookInvoker) *loadBalancerController {
+	recorder record.EventRecorder, invoker util.WebhookInvoker,
+	inFlightLimiter *util.InFlightLimiter) *loadBalancerController {
 	return &loadBalancerController{
-		lbcfClient:     client,
-		lister:         lbLister,
-		driverLister:   driverLister,
-		eventRecorder:  recorder,
-		webhookInvoker: invoker,
+		lbcfClient:      client,
+		lister:          lbLister,
+		driverLister:    driverLister,
+		eventRecorder:   recorder,
+		webhookInvoker:  invoker,
+		inFlightLimiter: inFlightLimiter,
 	}
 }
 
@@ -53,8 +55,9 @@ type loadBalancerController struct {
 	lister       v1beta1.LoadBalancerLister
 	driverLister v1beta1.LoadBalancerDriverLister
 
-	eventRecorder  record.EventRecorder
-	webhookInvoker util.WebhookInvoker
+	eventRecorder   record.EventRecorder
+	webhookInvoker  util.WebhookInvoker
+	inFlightLimiter *util.InFlightLimiter
 }
 
 func (c *loadBalancerController) syncLB(key string) *util.SyncResult {
@@ -69,14 +72,29 @@ func (c *loadBalancerController) syncLB(key string) *util.SyncResult {
 		return util.ErrorResult(err)
 	}
 
+	if lb.DeletionTimestamp != nil && !util.HasFinalizer(lb.Finalizers, lbcfapi.FinalizerDeleteLB) {
+		return util.FinishedResult()
+	}
+
+	driverNamespace := util.GetDriverNamespace(lb.Spec.LBDriver, lb.Namespace)
+	driverKey := util.NamespacedNameKeyFunc(driverNamespace, lb.Spec.LBDriver)
+	driver, err := c.driverLister.LoadBalancerDrivers(driverNamespace).Get(lb.Spec.LBDriver)
+	if err == nil && !util.IsDriverHealthy(driver) {
+		return util.FailResult(util.DriverUnhealthyRetryDelay, fmt.Sprintf("driver %s is unhealthy", driverKey))
+	}
+	if !c.inFlightLimiter.TryAcquire(driverKey) {
+		return util.ThrottledResult()
+	}
+	defer c.inFlightLimiter.Release(driverKey)
+
 	if lb.DeletionTimestamp != nil {
-		if !util.HasFinalizer(lb.Finalizers, lbcfapi.FinalizerDeleteLB) {
-			return util.FinishedResult()
-		}
 		return c.deleteLoadBalancer(lb)
 	}
 
 	if !util.LBCreated(lb) {
+		if lb.Spec.Import {
+			return c.importLoadBalancer(lb)
+		}
 		return c.createLoadBalancer(lb)
 	}
 	return c.ensureLoadBalancer(lb)
@@ -99,7 +117,7 @@ func (c *loadBalancerController) createLoadBalancer(lb *lbcfapi.LoadBalancer) *u
 	}
 	rsp, err := c.webhookInvoker.CallCreateLoadBalancer(driver, req)
 	if err != nil {
-		return util.ErrorResult(err)
+		return util.WebhookErrorResult(err)
 	}
 	switch rsp.Status {
 	case webhooks.StatusSucc:
@@ -151,6 +169,79 @@ func (c *loadBalancerController) createLoadBalancer(lb *lbcfapi.LoadBalancer) *u
 	}
 }
 
+func (c *loadBalancerController) importLoadBalancer(lb *lbcfapi.LoadBalancer) *util.SyncResult {
+	driver, err := c.driverLister.LoadBalancerDrivers(
+		util.GetDriverNamespace(lb.Spec.LBDriver, lb.Namespace)).Get(lb.Spec.LBDriver)
+	if err != nil {
+		return util.ErrorResult(
+			fmt.Errorf("retrieve driver %q for LoadBalancer %s failed: %v", lb.Spec.LBDriver, lb.Name, err))
+	}
+	if !util.DriverImplements(driver, webhooks.ImportLoadBalancer) {
+		msg := fmt.Sprintf("driver %s does not implement webhook %s", lb.Spec.LBDriver, webhooks.ImportLoadBalancer)
+		c.eventRecorder.Eventf(lb, apicore.EventTypeWarning, "FailedImportLoadBalancer", msg)
+		return util.FailResult(util.CalculateRetryInterval(0), msg)
+	}
+	req := &webhooks.ImportLoadBalancerRequest{
+		RequestForRetryHooks: webhooks.RequestForRetryHooks{
+			RecordID: fmt.Sprintf("importLoadBalancer(%s)", lb.UID),
+			RetryID:  string(uuid.NewUUID()),
+		},
+		LBSpec:     lb.Spec.LBSpec,
+		Attributes: lb.Spec.Attributes,
+	}
+	rsp, err := c.webhookInvoker.CallImportLoadBalancer(driver, req)
+	if err != nil {
+		return util.WebhookErrorResult(err)
+	}
+	switch rsp.Status {
+	case webhooks.StatusSucc:
+		lb = lb.DeepCopy()
+		if len(rsp.LBInfo) > 0 {
+			lb.Status.LBInfo = rsp.LBInfo
+		} else {
+			lb.Status.LBInfo = lb.Spec.LBSpec
+		}
+		// an imported LoadBalancer is regarded as created, so that backends can be registered to it
+		util.AddLBCondition(&lb.Status, lbcfapi.LoadBalancerCondition{
+			Type:               lbcfapi.LBCreated,
+			Status:             lbcfapi.ConditionTrue,
+			LastTransitionTime: v1.Now(),
+			Message:            rsp.Msg,
+		})
+		util.AddLBCondition(&lb.Status, lbcfapi.LoadBalancerCondition{
+			Type:               lbcfapi.LBImported,
+			Status:             lbcfapi.ConditionTrue,
+			LastTransitionTime: v1.Now(),
+			Message:            rsp.Msg,
+		})
+		_, err := c.lbcfClient.LbcfV1beta1().LoadBalancers(lb.Namespace).UpdateStatus(lb)
+		if err != nil {
+			c.eventRecorder.Eventf(lb,
+				apicore.EventTypeWarning,
+				"FailedImportLoadBalancer", "update status failed: %v", err)
+			return util.ErrorResult(err)
+		}
+		c.eventRecorder.Eventf(lb,
+			apicore.EventTypeNormal, "SuccImportLoadBalancer", "Successfully imported load balancer")
+		// attributes are not synced by importLoadBalancer
+		return util.AsyncResult(0)
+	case webhooks.StatusFail:
+		c.eventRecorder.Eventf(lb,
+			apicore.EventTypeWarning, "FailedImportLoadBalancer", "msg: %s", rsp.Msg)
+		return util.FailResult(util.CalculateRetryInterval(rsp.MinRetryDelayInSeconds), rsp.Msg)
+	case webhooks.StatusRunning:
+		c.eventRecorder.Eventf(lb,
+			apicore.EventTypeNormal, "RunningImportLoadBalancer", "msg: %s", rsp.Msg)
+		delay := util.CalculateRetryInterval(rsp.MinRetryDelayInSeconds)
+		return util.AsyncResult(delay)
+	default:
+		c.eventRecorder.Eventf(lb,
+			apicore.EventTypeWarning,
+			"InvalidImportLoadBalancer", "unsupported status: %s, msg: %s", rsp.Status, rsp.Msg)
+		return util.ErrorResult(fmt.Errorf("unknown status %q", rsp.Status))
+	}
+}
+
 func (c *loadBalancerController) ensureLoadBalancer(lb *lbcfapi.LoadBalancer) *util.SyncResult {
 	driver, err := c.driverLister.LoadBalancerDrivers(
 		util.GetDriverNamespace(lb.Spec.LBDriver, lb.Namespace)).Get(lb.Spec.LBDriver)
@@ -167,7 +258,7 @@ func (c *loadBalancerController) ensureLoadBalancer(lb *lbcfapi.LoadBalancer) *u
 	}
 	rsp, err := c.webhookInvoker.CallEnsureLoadBalancer(driver, req)
 	if err != nil {
-		return util.ErrorResult(err)
+		return util.WebhookErrorResult(err)
 	}
 	switch rsp.Status {
 	case webhooks.StatusSucc:
@@ -224,6 +315,11 @@ func (c *loadBalancerController) ensureLoadBalancer(lb *lbcfapi.LoadBalancer) *u
 }
 
 func (c *loadBalancerController) deleteLoadBalancer(lb *lbcfapi.LoadBalancer) *util.SyncResult {
+	if util.GetDeletionPolicy(lb) == lbcfapi.DeletionPolicyRetain {
+		c.eventRecorder.Eventf(lb, apicore.EventTypeNormal, "RetainLoadBalancer",
+			"load balancer is retained, lbInfo: %v", lb.Status.LBInfo)
+		return c.removeFinalizer(lb)
+	}
 	driver, err := c.driverLister.LoadBalancerDrivers(
 		util.GetDriverNamespace(lb.Spec.LBDriver, lb.Namespace)).Get(lb.Spec.LBDriver)
 	if err != nil {
@@ -240,7 +336,7 @@ func (c *loadBalancerController) deleteLoadBalancer(lb *lbcfapi.LoadBalancer) *u
 	}
 	rsp, err := c.webhookInvoker.CallDeleteLoadBalancer(driver, req)
 	if err != nil {
-		return util.ErrorResult(err)
+		return util.WebhookErrorResult(err)
 	}
 	switch rsp.Status {
 	case webhooks.StatusSucc:
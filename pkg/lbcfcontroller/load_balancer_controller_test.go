@@ -0,0 +1,159 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package lbcfcontroller
+
+import (
+	"strings"
+	"testing"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	lbcffake "tkestack.io/lb-controlling-framework/pkg/client-go/clientset/versioned/fake"
+	"tkestack.io/lb-controlling-framework/pkg/client-go/listers/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+type fakeDeleteLBInvoker struct {
+	util.WebhookInvoker
+	status  string
+	deletes int
+}
+
+func (f *fakeDeleteLBInvoker) CallDeleteLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.DeleteLoadBalancerRequest) (*webhooks.DeleteLoadBalancerResponse, error) {
+	f.deletes++
+	rsp := &webhooks.DeleteLoadBalancerResponse{}
+	rsp.Status = f.status
+	rsp.MinRetryDelayInSeconds = 10
+	return rsp, nil
+}
+
+func newTestDeletingLB(policy lbcfapi.DeletionPolicy, imported bool) *lbcfapi.LoadBalancer {
+	now := metav1.Now()
+	return &lbcfapi.LoadBalancer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "lb",
+			Namespace:         "default",
+			UID:               "lb-uid",
+			DeletionTimestamp: &now,
+			Finalizers:        []string{lbcfapi.FinalizerDeleteLB},
+		},
+		Spec: lbcfapi.LoadBalancerSpec{
+			LBDriver:       "lbcf-driver",
+			Import:         imported,
+			DeletionPolicy: policy,
+		},
+		Status: lbcfapi.LoadBalancerStatus{
+			LBInfo: map[string]string{"lbID": "lb-1"},
+		},
+	}
+}
+
+func TestLoadBalancerFinalization(t *testing.T) {
+	cases := []struct {
+		name             string
+		policy           lbcfapi.DeletionPolicy
+		imported         bool
+		webhookStatus    string
+		expectWebhook    bool
+		expectFinalizer  bool
+		expectRetainNote bool
+	}{
+		{
+			name:             "retain",
+			policy:           lbcfapi.DeletionPolicyRetain,
+			expectRetainNote: true,
+		},
+		{
+			name:             "imported-defaults-to-retain",
+			imported:         true,
+			expectRetainNote: true,
+		},
+		{
+			name:          "delete",
+			policy:        lbcfapi.DeletionPolicyDelete,
+			webhookStatus: webhooks.StatusSucc,
+			expectWebhook: true,
+		},
+		{
+			name:          "created-defaults-to-delete",
+			webhookStatus: webhooks.StatusSucc,
+			expectWebhook: true,
+		},
+		{
+			name:          "imported-with-delete",
+			policy:        lbcfapi.DeletionPolicyDelete,
+			imported:      true,
+			webhookStatus: webhooks.StatusSucc,
+			expectWebhook: true,
+		},
+		{
+			name:            "delete-running",
+			policy:          lbcfapi.DeletionPolicyDelete,
+			webhookStatus:   webhooks.StatusRunning,
+			expectWebhook:   true,
+			expectFinalizer: true,
+		},
+		{
+			name:            "delete-failed",
+			policy:          lbcfapi.DeletionPolicyDelete,
+			webhookStatus:   webhooks.StatusFail,
+			expectWebhook:   true,
+			expectFinalizer: true,
+		},
+	}
+	for _, c := range cases {
+		lb := newTestDeletingLB(c.policy, c.imported)
+		driver := newTestProbeDriver()
+		client := lbcffake.NewSimpleClientset(lb)
+		recorder := record.NewFakeRecorder(10)
+		invoker := &fakeDeleteLBInvoker{status: c.webhookStatus}
+		ctrl := newLoadBalancerController(client,
+			v1beta1.NewLoadBalancerLister(newTestIndexer(lb)),
+			v1beta1.NewLoadBalancerDriverLister(newTestIndexer(driver)),
+			recorder, invoker, util.NewInFlightLimiter(0))
+
+		result := ctrl.syncLB("default/lb")
+
+		if called := invoker.deletes > 0; called != c.expectWebhook {
+			t.Errorf("case %s: expect webhook called %v, got %v", c.name, c.expectWebhook, called)
+		}
+		if result.IsFinished() == c.expectFinalizer {
+			t.Errorf("case %s: expect finished %v, got %+v", c.name, !c.expectFinalizer, result)
+		}
+		got, err := client.LbcfV1beta1().LoadBalancers("default").Get("lb", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("case %s: %v", c.name, err)
+		}
+		if has := util.HasFinalizer(got.Finalizers, lbcfapi.FinalizerDeleteLB); has != c.expectFinalizer {
+			t.Errorf("case %s: expect finalizer %v, got %v", c.name, c.expectFinalizer, has)
+		}
+		retained := false
+		for len(recorder.Events) > 0 {
+			if strings.Contains(<-recorder.Events, "RetainLoadBalancer") {
+				retained = true
+			}
+		}
+		if retained != c.expectRetainNote {
+			t.Errorf("case %s: expect RetainLoadBalancer event %v, got %v", c.name, c.expectRetainNote, retained)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	lbcflister "tkestack.io/lb-controlling-framework/pkg/client-go/listers/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog"
+)
+
+const namespace = "lbcf"
+
+const (
+	// WebhookStatusError is the status of webhook calls that failed without a valid response,
+	// e.g., timeout, connection refused or invalid response body
+	WebhookStatusError = "Error"
+	// WebhookStatusUnknown is the status of webhook calls that returned a status not defined in webhook specification
+	WebhookStatusUnknown = "Unknown"
+)
+
+const (
+	// SyncResultFinished indicates util.SyncResult.IsFinished() is true
+	SyncResultFinished = "finished"
+	// SyncResultFailed indicates util.SyncResult.IsFailed() is true
+	SyncResultFailed = "failed"
+	// SyncResultRunning indicates util.SyncResult.IsRunning() is true
+	SyncResultRunning = "running"
+	// SyncResultPeriodic indicates util.SyncResult.IsPeriodic() is true
+	SyncResultPeriodic = "periodic"
+	// SyncResultThrottled indicates util.SyncResult.IsThrottled() is true
+	SyncResultThrottled = "throttled"
+)
+
+var (
+	webhookLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "webhook_duration_seconds",
+		Help:      "Latency of webhook calls to drivers.",
+		Buckets:   []float64{0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"driver", "webhook"})
+
+	webhookCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_calls_total",
+		Help:      "Number of webhook calls to drivers, partitioned by the status in webhook response.",
+	}, []string{"driver", "webhook", "status"})
+
+	syncResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sync_results_total",
+		Help:      "Number of sync results, partitioned by controller and result.",
+	}, []string{"controller", "result"})
+
+	queueRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queue_retries_total",
+		Help:      "Number of failed items that are added back to the queue for retry.",
+	}, []string{"queue"})
+
+	queueDepthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "queue_depth"),
+		"Number of items waiting in the queue.",
+		[]string{"queue"}, nil)
+
+	queueWaitingForFilterDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "queue_waiting_for_filter"),
+		"Number of items that are delayed and not yet filtered before being added to the queue.",
+		[]string{"queue"}, nil)
+
+	backendGroupBackendsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "backendgroup_backends"),
+		"Number of backends in BackendGroup status.",
+		[]string{"namespace", "backendgroup"}, nil)
+
+	backendGroupRegisteredBackendsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "backendgroup_registered_backends"),
+		"Number of registered backends in BackendGroup status.",
+		[]string{"namespace", "backendgroup"}, nil)
+)
+
+func init() {
+	prometheus.MustRegister(webhookLatency, webhookCalls, syncResults, queueRetries)
+}
+
+// Handler returns the http handler serving /metrics
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// WebhookCalled records a webhook call to driver.
+//
+// status is returned by driver, it is recorded as WebhookStatusUnknown if not defined in webhook specification,
+// so that a misbehaving driver can not create unlimited time series
+func WebhookCalled(driver string, webhook string, status string, elapsed time.Duration) {
+	webhookLatency.WithLabelValues(driver, webhook).Observe(elapsed.Seconds())
+	webhookCalls.WithLabelValues(driver, webhook, normalizeWebhookStatus(status)).Inc()
+}
+
+func normalizeWebhookStatus(status string) string {
+	switch status {
+	case webhooks.StatusSucc, webhooks.StatusFail, webhooks.StatusRunning, WebhookStatusError:
+		return status
+	default:
+		return WebhookStatusUnknown
+	}
+}
+
+// SyncFinished records the result of a sync of controller
+func SyncFinished(controller string, result string) {
+	syncResults.WithLabelValues(controller, result).Inc()
+}
+
+// QueueRetried records an item is added back to queue for retry
+func QueueRetried(queue string) {
+	queueRetries.WithLabelValues(queue).Inc()
+}
+
+// Queue is the part of util.ConditionalRateLimitingInterface that metrics are collected from
+type Queue interface {
+	Len() int
+	LenWaitingForFilter() int
+}
+
+// RegisterQueues registers a collector collecting depth of queues, the key of queues is used as queue name
+func RegisterQueues(queues map[string]Queue) {
+	if err := prometheus.Register(&queueCollector{queues: queues}); err != nil {
+		klog.Errorf("register queue metrics failed: %v", err)
+	}
+}
+
+type queueCollector struct {
+	queues map[string]Queue
+}
+
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueDepthDesc
+	ch <- queueWaitingForFilterDesc
+}
+
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, q := range c.queues {
+		ch <- prometheus.MustNewConstMetric(queueDepthDesc, prometheus.GaugeValue, float64(q.Len()), name)
+		ch <- prometheus.MustNewConstMetric(queueWaitingForFilterDesc, prometheus.GaugeValue,
+			float64(q.LenWaitingForFilter()), name)
+	}
+}
+
+// RegisterBackendGroups registers a collector collecting backend numbers from BackendGroup status
+func RegisterBackendGroups(lister lbcflister.BackendGroupLister) {
+	if err := prometheus.Register(&backendGroupCollector{lister: lister}); err != nil {
+		klog.Errorf("register backendgroup metrics failed: %v", err)
+	}
+}
+
+type backendGroupCollector struct {
+	lister lbcflister.BackendGroupLister
+}
+
+func (c *backendGroupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- backendGroupBackendsDesc
+	ch <- backendGroupRegisteredBackendsDesc
+}
+
+func (c *backendGroupCollector) Collect(ch chan<- prometheus.Metric) {
+	groups, err := c.lister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("collect backendgroup metrics failed: %v", err)
+		return
+	}
+	for _, group := range groups {
+		ch <- prometheus.MustNewConstMetric(backendGroupBackendsDesc, prometheus.GaugeValue,
+			float64(group.Status.Backends), group.Namespace, group.Name)
+		ch <- prometheus.MustNewConstMetric(backendGroupRegisteredBackendsDesc, prometheus.GaugeValue,
+			float64(group.Status.RegisteredBackends), group.Namespace, group.Name)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNormalizeWebhookStatus(t *testing.T) {
+	cases := map[string]string{
+		webhooks.StatusSucc:    webhooks.StatusSucc,
+		webhooks.StatusFail:    webhooks.StatusFail,
+		webhooks.StatusRunning: webhooks.StatusRunning,
+		WebhookStatusError:     WebhookStatusError,
+		"":                     WebhookStatusUnknown,
+		"succ":                 WebhookStatusUnknown,
+		"request id 123 done":  WebhookStatusUnknown,
+	}
+	for status, expect := range cases {
+		if got := normalizeWebhookStatus(status); got != expect {
+			t.Errorf("status %q: expect %q, got %q", status, expect, got)
+		}
+	}
+}
+
+func TestWebhookCalledBoundsStatus(t *testing.T) {
+	driver := "kube-system/test-driver"
+	for _, status := range []string{"random-1", "random-2", "random-3"} {
+		WebhookCalled(driver, webhooks.EnsureBackend, status, time.Millisecond)
+	}
+	WebhookCalled(driver, webhooks.EnsureBackend, webhooks.StatusSucc, time.Millisecond)
+
+	if got := testutil.ToFloat64(webhookCalls.WithLabelValues(driver, webhooks.EnsureBackend, WebhookStatusUnknown)); got != 3 {
+		t.Errorf("expect 3 calls with unknown status, got %v", got)
+	}
+	if got := testutil.ToFloat64(webhookCalls.WithLabelValues(driver, webhooks.EnsureBackend, webhooks.StatusSucc)); got != 1 {
+		t.Errorf("expect 1 succeeded call, got %v", got)
+	}
+	if n := testutil.CollectAndCount(webhookCalls); n != 2 {
+		t.Errorf("expect 2 series, got %d", n)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package lbcfcontroller
+
+import (
+	"fmt"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/client-go/listers/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	corev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func newPodDrainer(client kubernetes.Interface,
+	podLister corev1.PodLister,
+	brLister v1beta1.BackendRecordLister,
+	recorder record.EventRecorder) *podDrainer {
+	return &podDrainer{
+		client:        client,
+		podLister:     podLister,
+		brLister:      brLister,
+		eventRecorder: recorder,
+	}
+}
+
+// podDrainer keeps the Pod objects selected by BackendGroups in drain mode until their backends are drained.
+//
+// A finalizer is added to these pods, and it is removed only after all BackendRecords
+// of the pod that need draining are deregistered and drained. The finalizer keeps the Pod object,
+// e.g., a StatefulSet can not recreate a pod with the same name before the old backend is drained,
+// but it does NOT keep the containers running: kubelet sends SIGTERM as soon as the pod is deleted.
+// Containers must have a preStop hook that lasts longer than deregistration and drainPeriod,
+// otherwise in-flight requests are not drained, a warning event is recorded for pods without preStop hook.
+type podDrainer struct {
+	client        kubernetes.Interface
+	podLister     corev1.PodLister
+	brLister      v1beta1.BackendRecordLister
+	eventRecorder record.EventRecorder
+}
+
+// sync adds finalizer to running pods and tries to remove finalizer from deleting pods
+func (d *podDrainer) sync(pods []*v1.Pod) error {
+	var errList util.ErrorList
+	for _, pod := range pods {
+		var err error
+		if pod.DeletionTimestamp == nil {
+			err = d.ensureFinalizer(pod)
+		} else {
+			err = d.release(pod, "")
+		}
+		if err != nil {
+			errList = append(errList, err)
+		}
+	}
+	if len(errList) > 0 {
+		return errList
+	}
+	return nil
+}
+
+// syncPod removes finalizer from the deleting pod identified by key, it is run by workers of the leader
+func (d *podDrainer) syncPod(key string) *util.SyncResult {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return util.ErrorResult(err)
+	}
+	if err := d.releaseByName(namespace, name, ""); err != nil {
+		return util.ErrorResult(err)
+	}
+	return util.FinishedResult()
+}
+
+func (d *podDrainer) ensureFinalizer(pod *v1.Pod) error {
+	if util.HasFinalizer(pod.Finalizers, lbcfapi.FinalizerDrainPod) {
+		return nil
+	}
+	pod = pod.DeepCopy()
+	pod.Finalizers = append(pod.Finalizers, lbcfapi.FinalizerDrainPod)
+	if _, err := d.client.CoreV1().Pods(pod.Namespace).Update(pod); err != nil {
+		return fmt.Errorf("add finalizer to pod %s/%s failed: %v", pod.Namespace, pod.Name, err)
+	}
+	if !hasPreStopHook(pod) {
+		d.eventRecorder.Eventf(pod,
+			v1.EventTypeWarning,
+			"MissingPreStopHook",
+			"pod is selected by BackendGroup in drain mode, but none of its containers has a preStop hook, "+
+				"requests may be dropped because containers are terminated before the backend is drained")
+	}
+	return nil
+}
+
+// releaseByName is the same as release except that the pod is retrieved by name
+func (d *podDrainer) releaseByName(namespace, name string, finishedRecord string) error {
+	pod, err := d.podLister.Pods(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return d.release(pod, finishedRecord)
+}
+
+// release removes finalizer from the pod if the pod is deleting and none of its BackendRecords is waiting for drain.
+// finishedRecord is the name of the BackendRecord that just finished draining but may still be seen in lister
+func (d *podDrainer) release(pod *v1.Pod, finishedRecord string) error {
+	if pod.DeletionTimestamp == nil || !util.HasFinalizer(pod.Finalizers, lbcfapi.FinalizerDrainPod) {
+		return nil
+	}
+	selector := labels.SelectorFromSet(labels.Set{lbcfapi.LabelPodName: pod.Name})
+	records, err := d.brLister.BackendRecords(pod.Namespace).List(selector)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if record.Name != finishedRecord && util.NeedDrain(record) && !util.BackendDrained(record) {
+			return nil
+		}
+	}
+	pod = pod.DeepCopy()
+	pod.Finalizers = util.RemoveFinalizer(pod.Finalizers, lbcfapi.FinalizerDrainPod)
+	if _, err := d.client.CoreV1().Pods(pod.Namespace).Update(pod); err != nil {
+		return fmt.Errorf("remove finalizer from pod %s/%s failed: %v", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// needRelease indicates whether the pod is deleting and its drain finalizer should be checked
+func needRelease(pod *v1.Pod) bool {
+	return pod.DeletionTimestamp != nil && util.HasFinalizer(pod.Finalizers, lbcfapi.FinalizerDrainPod)
+}
+
+func hasPreStopHook(pod *v1.Pod) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Lifecycle != nil && container.Lifecycle.PreStop != nil {
+			return true
+		}
+	}
+	return false
+}
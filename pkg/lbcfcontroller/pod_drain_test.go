@@ -0,0 +1,300 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package lbcfcontroller
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	lbcffake "tkestack.io/lb-controlling-framework/pkg/client-go/clientset/versioned/fake"
+	lbcflister "tkestack.io/lb-controlling-framework/pkg/client-go/listers/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/util"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func newTestIndexer(objs ...interface{}) cache.Indexer {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range objs {
+		indexer.Add(obj)
+	}
+	return indexer
+}
+
+func newTestPod(name string, deleting bool, finalizers ...string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			ResourceVersion: "1",
+			Finalizers:      finalizers,
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "app"}},
+		},
+	}
+	if deleting {
+		now := metav1.Now()
+		pod.DeletionTimestamp = &now
+	}
+	return pod
+}
+
+func newTestDrainRecord(name string, podName string, drained *lbcfapi.ConditionStatus) *lbcfapi.BackendRecord {
+	record := &lbcfapi.BackendRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  "default",
+			Labels:     map[string]string{lbcfapi.LabelPodName: podName},
+			Finalizers: []string{lbcfapi.FinalizerDeregisterBackend},
+		},
+		Spec: lbcfapi.BackendRecordSpec{
+			PodBackendInfo: &lbcfapi.PodBackendRecord{
+				Name:        podName,
+				DrainPeriod: &lbcfapi.Duration{Duration: 10 * time.Second},
+			},
+		},
+	}
+	if drained != nil {
+		record.Status.Conditions = []lbcfapi.BackendRecordCondition{
+			{
+				Type:               lbcfapi.BackendDrained,
+				Status:             *drained,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+			},
+		}
+	}
+	return record
+}
+
+func newTestDrainer(pods []*v1.Pod, records []*lbcfapi.BackendRecord) (*podDrainer, *fake.Clientset, *record.FakeRecorder) {
+	var podObjs, brObjs []interface{}
+	var clientObjs []runtime.Object
+	for _, pod := range pods {
+		podObjs = append(podObjs, pod)
+		clientObjs = append(clientObjs, pod)
+	}
+	for _, r := range records {
+		brObjs = append(brObjs, r)
+	}
+	client := fake.NewSimpleClientset(clientObjs...)
+	recorder := record.NewFakeRecorder(10)
+	drainer := newPodDrainer(client,
+		corev1.NewPodLister(newTestIndexer(podObjs...)),
+		lbcflister.NewBackendRecordLister(newTestIndexer(brObjs...)),
+		recorder)
+	return drainer, client, recorder
+}
+
+func getTestPod(t *testing.T, client *fake.Clientset, name string) *v1.Pod {
+	pod, err := client.CoreV1().Pods("default").Get(name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get pod failed: %v", err)
+	}
+	return pod
+}
+
+func TestPodDrainerEnsureFinalizer(t *testing.T) {
+	withoutHook := newTestPod("without-hook", false)
+	withHook := newTestPod("with-hook", false)
+	withHook.Spec.Containers[0].Lifecycle = &v1.Lifecycle{
+		PreStop: &v1.Handler{Exec: &v1.ExecAction{Command: []string{"sleep", "30"}}},
+	}
+	drainer, client, recorder := newTestDrainer([]*v1.Pod{withoutHook, withHook}, nil)
+
+	if err := drainer.sync([]*v1.Pod{withoutHook, withHook}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	for _, name := range []string{"without-hook", "with-hook"} {
+		if !util.HasFinalizer(getTestPod(t, client, name).Finalizers, lbcfapi.FinalizerDrainPod) {
+			t.Errorf("expect finalizer to be added to pod %s", name)
+		}
+	}
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	if len(events) != 1 || !strings.Contains(events[0], "MissingPreStopHook") {
+		t.Errorf("expect exactly one MissingPreStopHook event, got %v", events)
+	}
+}
+
+func TestPodDrainerRelease(t *testing.T) {
+	isFalse := lbcfapi.ConditionFalse
+	isTrue := lbcfapi.ConditionTrue
+	cases := []struct {
+		name           string
+		records        []*lbcfapi.BackendRecord
+		finishedRecord string
+		expectReleased bool
+	}{
+		{
+			name:           "no-record",
+			expectReleased: true,
+		},
+		{
+			name:    "deregistering",
+			records: []*lbcfapi.BackendRecord{newTestDrainRecord("r1", "pod", nil)},
+		},
+		{
+			name:    "draining",
+			records: []*lbcfapi.BackendRecord{newTestDrainRecord("r1", "pod", &isFalse)},
+		},
+		{
+			name:           "drained",
+			records:        []*lbcfapi.BackendRecord{newTestDrainRecord("r1", "pod", &isTrue)},
+			expectReleased: true,
+		},
+		{
+			name:           "just-finished",
+			records:        []*lbcfapi.BackendRecord{newTestDrainRecord("r1", "pod", &isFalse)},
+			finishedRecord: "r1",
+			expectReleased: true,
+		},
+		{
+			name: "other-draining",
+			records: []*lbcfapi.BackendRecord{
+				newTestDrainRecord("r1", "pod", &isTrue),
+				newTestDrainRecord("r2", "pod", &isFalse),
+			},
+			finishedRecord: "r1",
+		},
+		{
+			name:           "other-pod",
+			records:        []*lbcfapi.BackendRecord{newTestDrainRecord("r1", "other-pod", &isFalse)},
+			expectReleased: true,
+		},
+	}
+	for _, c := range cases {
+		pod := newTestPod("pod", true, lbcfapi.FinalizerDrainPod)
+		drainer, client, _ := newTestDrainer([]*v1.Pod{pod}, c.records)
+		if err := drainer.releaseByName("default", "pod", c.finishedRecord); err != nil {
+			t.Fatalf("case %s: expect no error, got %v", c.name, err)
+		}
+		released := !util.HasFinalizer(getTestPod(t, client, "pod").Finalizers, lbcfapi.FinalizerDrainPod)
+		if released != c.expectReleased {
+			t.Errorf("case %s: expect released %v, got %v", c.name, c.expectReleased, released)
+		}
+	}
+}
+
+func TestPodDrainerSyncPod(t *testing.T) {
+	pod := newTestPod("pod", true, lbcfapi.FinalizerDrainPod)
+	drainer, client, _ := newTestDrainer([]*v1.Pod{pod}, nil)
+
+	if result := drainer.syncPod("default/pod"); !result.IsFinished() {
+		t.Fatalf("expect finished, got %+v", result)
+	}
+	if util.HasFinalizer(getTestPod(t, client, "pod").Finalizers, lbcfapi.FinalizerDrainPod) {
+		t.Errorf("expect finalizer to be removed")
+	}
+	if result := drainer.syncPod("default/not-exist"); !result.IsFinished() {
+		t.Errorf("expect finished for missing pod, got %+v", result)
+	}
+}
+
+func TestUpdatePodOnlyEnqueuesDeletingPod(t *testing.T) {
+	running := newTestPod("pod", false, lbcfapi.FinalizerDrainPod)
+	deleting := newTestPod("pod", true, lbcfapi.FinalizerDrainPod)
+	drainer, client, _ := newTestDrainer([]*v1.Pod{deleting}, nil)
+	c := &Controller{
+		podDrainer: drainer,
+		podQueue:   util.NewConditionalDelayingQueue(nil, time.Second, time.Second, time.Second),
+	}
+	defer c.podQueue.ShutDown()
+
+	// resync does not enqueue
+	c.updatePod(deleting, deleting)
+	if c.podQueue.Len() != 0 {
+		t.Fatalf("expect resync not to enqueue pod")
+	}
+
+	deleting.ResourceVersion = "2"
+	c.updatePod(running, deleting)
+	if c.podQueue.Len() != 1 {
+		t.Fatalf("expect deleting pod to be enqueued, queue length %d", c.podQueue.Len())
+	}
+	key, _ := c.podQueue.Get()
+	if key != "default/pod" {
+		t.Errorf("unexpected key %v", key)
+	}
+	if len(client.Actions()) != 0 {
+		t.Errorf("expect no API call in event handler, got %v", client.Actions())
+	}
+}
+
+func TestWaitDrainSetsDrainedCondition(t *testing.T) {
+	isFalse := lbcfapi.ConditionFalse
+	backend := newTestDrainRecord("r1", "pod", &isFalse)
+	now := metav1.Now()
+	backend.DeletionTimestamp = &now
+
+	client := lbcffake.NewSimpleClientset(backend)
+	drainer, _, _ := newTestDrainer(nil, nil)
+	c := &backendController{
+		client:             client,
+		eventRecorder:      record.NewFakeRecorder(10),
+		inProgressDeleting: new(sync.Map),
+		podDrainer:         drainer,
+	}
+
+	cond := util.GetBackendRecordCondition(&backend.Status, lbcfapi.BackendDrained)
+	if result := c.waitDrain(backend, cond); !result.IsFinished() {
+		t.Fatalf("expect finished, got %+v", result)
+	}
+	updated, err := client.LbcfV1beta1().BackendRecords("default").Get("r1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get backend failed: %v", err)
+	}
+	if !util.BackendDrained(updated) {
+		t.Errorf("expect condition Drained to be True, got %+v", updated.Status.Conditions)
+	}
+	if util.HasFinalizer(updated.Finalizers, lbcfapi.FinalizerDeregisterBackend) {
+		t.Errorf("expect finalizer to be removed")
+	}
+}
+
+func TestWaitDrainBeforeDrainPeriod(t *testing.T) {
+	isFalse := lbcfapi.ConditionFalse
+	backend := newTestDrainRecord("r1", "pod", &isFalse)
+	backend.Status.Conditions[0].LastTransitionTime = metav1.Now()
+
+	client := lbcffake.NewSimpleClientset(backend)
+	c := &backendController{
+		client:             client,
+		eventRecorder:      record.NewFakeRecorder(10),
+		inProgressDeleting: new(sync.Map),
+	}
+	cond := util.GetBackendRecordCondition(&backend.Status, lbcfapi.BackendDrained)
+	if result := c.waitDrain(backend, cond); !result.IsRunning() {
+		t.Fatalf("expect running, got %+v", result)
+	}
+	if len(client.Actions()) != 0 {
+		t.Errorf("expect no update before drainPeriod, got %v", client.Actions())
+	}
+}
@@ -0,0 +1,164 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// circuitWindow is the number of recent calls used to calculate the failure rate of a driver
+	circuitWindow = 20
+	// circuitMinCalls is the minimum number of calls in window before a circuit can be opened
+	circuitMinCalls = 10
+	// circuitFailureRate opens the circuit if reached
+	circuitFailureRate = 0.5
+	// CircuitOpenDuration is how long a circuit stays open before a single trial call is allowed
+	CircuitOpenDuration = 30 * time.Second
+)
+
+// CircuitOpenError is returned by WebhookInvoker if the call is rejected because the circuit of the driver is open
+type CircuitOpenError struct {
+	Driver     string
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit of driver %s is open, retry after %s", e.Driver, e.RetryAfter.String())
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{
+		circuits: make(map[string]*circuit),
+	}
+}
+
+// circuitBreaker tracks the failure rate of calls to each driver.
+//
+// A circuit is opened if the failure rate of the recent calls reaches circuitFailureRate,
+// calls to a driver with an open circuit fail fast with CircuitOpenError.
+// After CircuitOpenDuration, a single trial call is allowed, the circuit is closed if it succeeds,
+// otherwise the circuit is opened again.
+type circuitBreaker struct {
+	sync.Mutex
+	circuits map[string]*circuit
+	// onChange is called with the driver key when a circuit is opened or closed
+	onChange func(driver string)
+}
+
+type circuit struct {
+	// results of recent calls, true means failed
+	results  []bool
+	next     int
+	openedAt time.Time
+	open     bool
+	trialing bool
+}
+
+func (c *circuit) failures() int {
+	n := 0
+	for _, failed := range c.results {
+		if failed {
+			n++
+		}
+	}
+	return n
+}
+
+func (c *circuit) record(failed bool) {
+	if len(c.results) < circuitWindow {
+		c.results = append(c.results, failed)
+		return
+	}
+	c.results[c.next] = failed
+	c.next = (c.next + 1) % circuitWindow
+}
+
+// allow returns a CircuitOpenError if calls to driver should fail fast
+func (b *circuitBreaker) allow(driver string) error {
+	b.Lock()
+	defer b.Unlock()
+	c, ok := b.circuits[driver]
+	if !ok || !c.open {
+		return nil
+	}
+	if elapsed := time.Since(c.openedAt); elapsed < CircuitOpenDuration {
+		return &CircuitOpenError{Driver: driver, RetryAfter: CircuitOpenDuration - elapsed}
+	}
+	if c.trialing {
+		return &CircuitOpenError{Driver: driver, RetryAfter: CircuitOpenDuration}
+	}
+	c.trialing = true
+	return nil
+}
+
+// done records the result of a call allowed by allow
+func (b *circuitBreaker) done(driver string, err error) {
+	b.Lock()
+	c, ok := b.circuits[driver]
+	if !ok {
+		c = &circuit{}
+		b.circuits[driver] = c
+	}
+	changed := false
+	if c.open {
+		if !c.trialing {
+			// the call is allowed before the circuit is opened
+			b.Unlock()
+			return
+		}
+		c.trialing = false
+		if err != nil {
+			c.openedAt = time.Now()
+		} else {
+			*c = circuit{}
+			changed = true
+		}
+	} else {
+		c.record(err != nil)
+		if len(c.results) >= circuitMinCalls &&
+			float64(c.failures())/float64(len(c.results)) >= circuitFailureRate {
+			c.open = true
+			c.openedAt = time.Now()
+			changed = true
+		}
+	}
+	onChange := b.onChange
+	b.Unlock()
+
+	if changed && onChange != nil {
+		onChange(driver)
+	}
+}
+
+// isOpen returns true if the circuit of driver is open
+func (b *circuitBreaker) isOpen(driver string) bool {
+	b.Lock()
+	defer b.Unlock()
+	c, ok := b.circuits[driver]
+	return ok && c.open
+}
+
+// setOnChange replaces the function called when a circuit is opened or closed
+func (b *circuitBreaker) setOnChange(onChange func(driver string)) {
+	b.Lock()
+	defer b.Unlock()
+	b.onChange = onChange
+}
@@ -0,0 +1,129 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpens(t *testing.T) {
+	b := newCircuitBreaker()
+	var changes []string
+	b.setOnChange(func(driver string) { changes = append(changes, driver) })
+	errFailed := fmt.Errorf("failed")
+
+	for i := 0; i < circuitMinCalls-1; i++ {
+		b.done("driver", errFailed)
+	}
+	if b.isOpen("driver") {
+		t.Fatalf("expect circuit to stay closed before %d calls", circuitMinCalls)
+	}
+	b.done("driver", errFailed)
+	if !b.isOpen("driver") {
+		t.Fatalf("expect circuit to be opened")
+	}
+	if len(changes) != 1 || changes[0] != "driver" {
+		t.Errorf("expect onChange to be called once, got %v", changes)
+	}
+
+	err := b.allow("driver")
+	if _, ok := err.(*CircuitOpenError); !ok {
+		t.Fatalf("expect CircuitOpenError, got %v", err)
+	}
+	if err := b.allow("other-driver"); err != nil {
+		t.Errorf("expect other drivers not to be affected, got %v", err)
+	}
+}
+
+func TestCircuitBreakerFailureRate(t *testing.T) {
+	b := newCircuitBreaker()
+	errFailed := fmt.Errorf("failed")
+
+	// 9 failures out of 20 calls stays below circuitFailureRate
+	for i := 0; i < circuitWindow; i++ {
+		var err error
+		if i > circuitWindow-10 {
+			err = errFailed
+		}
+		b.done("driver", err)
+	}
+	if b.isOpen("driver") {
+		t.Fatalf("expect circuit to stay closed below failure rate")
+	}
+
+	// the oldest results are replaced once the window is full
+	b.done("driver", nil)
+	if n, total := b.circuits["driver"].failures(), len(b.circuits["driver"].results); n != 9 || total != circuitWindow {
+		t.Fatalf("expect 9 failures in window of %d, got %d in %d", circuitWindow, n, total)
+	}
+	b.done("driver", errFailed)
+	if !b.isOpen("driver") {
+		t.Fatalf("expect circuit to be opened when failure rate reaches %v", circuitFailureRate)
+	}
+}
+
+func TestCircuitBreakerTrial(t *testing.T) {
+	b := newCircuitBreaker()
+	changes := 0
+	b.setOnChange(func(string) { changes++ })
+	errFailed := fmt.Errorf("failed")
+	for i := 0; i < circuitMinCalls; i++ {
+		b.done("driver", errFailed)
+	}
+
+	// calls allowed before the circuit is opened do not affect the open circuit
+	b.done("driver", nil)
+	if !b.isOpen("driver") {
+		t.Fatalf("expect circuit to stay open")
+	}
+
+	b.circuits["driver"].openedAt = time.Now().Add(-CircuitOpenDuration)
+	if err := b.allow("driver"); err != nil {
+		t.Fatalf("expect a trial call to be allowed, got %v", err)
+	}
+	if err := b.allow("driver"); err == nil {
+		t.Fatalf("expect only one trial call to be allowed")
+	}
+
+	// failed trial opens the circuit again
+	b.done("driver", errFailed)
+	if !b.isOpen("driver") {
+		t.Fatalf("expect circuit to be opened again")
+	}
+	if err := b.allow("driver"); err == nil {
+		t.Fatalf("expect calls to be rejected after a failed trial")
+	}
+
+	// succeeded trial closes the circuit
+	b.circuits["driver"].openedAt = time.Now().Add(-CircuitOpenDuration)
+	if err := b.allow("driver"); err != nil {
+		t.Fatalf("expect a trial call to be allowed, got %v", err)
+	}
+	b.done("driver", nil)
+	if b.isOpen("driver") {
+		t.Fatalf("expect circuit to be closed")
+	}
+	if err := b.allow("driver"); err != nil {
+		t.Errorf("expect calls to be allowed after circuit closed, got %v", err)
+	}
+	if changes != 2 {
+		t.Errorf("expect onChange to be called on open and close, got %d", changes)
+	}
+}
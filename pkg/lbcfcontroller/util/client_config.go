@@ -0,0 +1,155 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"sync"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+
+	"k8s.io/api/core/v1"
+)
+
+// driverCredential is built from the Secrets referenced by LoadBalancerDriver.spec.clientConfig
+type driverCredential struct {
+	// tlsConfig is nil if neither CA nor client certificate is configured
+	tlsConfig *tls.Config
+	token     string
+
+	// version is made up of resourceVersions of the referenced Secrets,
+	// it changes whenever any of the Secrets is rotated
+	version string
+}
+
+// credentialStore caches driverCredentials and rebuilds them when the referenced Secrets change
+type credentialStore struct {
+	sync.Mutex
+	secrets     SecretGetter
+	credentials map[string]*driverCredential
+}
+
+func newCredentialStore(secrets SecretGetter) *credentialStore {
+	return &credentialStore{
+		secrets:     secrets,
+		credentials: make(map[string]*driverCredential),
+	}
+}
+
+func (s *credentialStore) get(driver *lbcfapi.LoadBalancerDriver) (*driverCredential, error) {
+	cfg := driver.Spec.ClientConfig
+	if cfg == nil {
+		return &driverCredential{}, nil
+	}
+	if s.secrets == nil {
+		return nil, fmt.Errorf("clientConfig is not supported: no secret getter")
+	}
+
+	var ca, cert, token *v1.Secret
+	var versions []string
+	var err error
+	if cfg.CASecret != "" {
+		if ca, err = s.secrets.GetSecret(driver.Namespace, cfg.CASecret); err != nil {
+			return nil, fmt.Errorf("get caSecret failed: %v", err)
+		}
+		versions = append(versions, "ca:"+ca.ResourceVersion)
+	}
+	if cfg.ClientCertSecret != "" {
+		if cert, err = s.secrets.GetSecret(driver.Namespace, cfg.ClientCertSecret); err != nil {
+			return nil, fmt.Errorf("get clientCertSecret failed: %v", err)
+		}
+		versions = append(versions, "cert:"+cert.ResourceVersion)
+	}
+	if cfg.TokenSecret != "" {
+		if token, err = s.secrets.GetSecret(driver.Namespace, cfg.TokenSecret); err != nil {
+			return nil, fmt.Errorf("get tokenSecret failed: %v", err)
+		}
+		versions = append(versions, "token:"+token.ResourceVersion)
+	}
+	version := strings.Join(versions, ",")
+
+	key := NamespacedNameKeyFunc(driver.Namespace, driver.Name)
+	s.Lock()
+	defer s.Unlock()
+	if cached, ok := s.credentials[key]; ok && cached.version == version {
+		return cached, nil
+	}
+	cred, err := buildDriverCredential(ca, cert, token)
+	if err != nil {
+		return nil, err
+	}
+	cred.version = version
+	s.credentials[key] = cred
+	return cred, nil
+}
+
+func buildDriverCredential(ca, cert, token *v1.Secret) (*driverCredential, error) {
+	cred := &driverCredential{}
+	if ca != nil || cert != nil {
+		cred.tlsConfig = &tls.Config{}
+	}
+	if ca != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca.Data[lbcfapi.SecretKeyCA]) {
+			return nil, fmt.Errorf("no valid certificate found in %s of secret %s", lbcfapi.SecretKeyCA, ca.Name)
+		}
+		cred.tlsConfig.RootCAs = pool
+	}
+	if cert != nil {
+		pair, err := tls.X509KeyPair(cert.Data[v1.TLSCertKey], cert.Data[v1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate in secret %s: %v", cert.Name, err)
+		}
+		cred.tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+	if token != nil {
+		cred.token = strings.TrimSpace(string(token.Data[lbcfapi.SecretKeyToken]))
+		if cred.token == "" {
+			return nil, fmt.Errorf("%s of secret %s is empty", lbcfapi.SecretKeyToken, token.Name)
+		}
+	}
+	return cred, nil
+}
+
+// checkTokenTransport returns an error if cred has a token but the connection to driver is not secured by TLS
+func checkTokenTransport(cred *driverCredential, secure bool) error {
+	if cred.token != "" && !secure {
+		return fmt.Errorf("refuse to send bearer token over an insecure connection")
+	}
+	return nil
+}
+
+// bearerToken implements credentials.PerRPCCredentials
+type bearerToken struct {
+	token string
+}
+
+func (b bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		"authorization": "Bearer " + b.token,
+	}, nil
+}
+
+// RequireTransportSecurity always returns true so that the token is never sent in cleartext
+func (b bearerToken) RequireTransportSecurity() bool {
+	return true
+}
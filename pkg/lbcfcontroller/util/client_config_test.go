@@ -0,0 +1,92 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestTokenSecretGetter() SecretGetter {
+	return NewSecretGetter(fake.NewSimpleClientset(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "driver-token",
+			Namespace:       "kube-system",
+			ResourceVersion: "1",
+		},
+		Data: map[string][]byte{lbcfapi.SecretKeyToken: []byte("abc")},
+	}).CoreV1())
+}
+
+func TestBearerTokenRequiresTransportSecurity(t *testing.T) {
+	if !(bearerToken{token: "abc"}).RequireTransportSecurity() {
+		t.Errorf("expect bearer token to require transport security")
+	}
+}
+
+func TestWebhookInvokerRefusesTokenOverHTTP(t *testing.T) {
+	var called int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.StoreInt32(&called, 1)
+	}))
+	defer server.Close()
+
+	driver := newGRPCDriver(server.URL)
+	driver.Spec.DriverType = string(lbcfapi.WebhookDriver)
+	driver.Spec.Webhooks = []lbcfapi.WebhookConfig{
+		{Name: webhooks.CreateLoadBalancer, Timeout: lbcfapi.Duration{Duration: 10 * time.Second}},
+	}
+	driver.Spec.ClientConfig = &lbcfapi.DriverClientConfig{TokenSecret: "driver-token"}
+	driver.Spec.HealthCheck = &lbcfapi.DriverHealthCheck{Path: "/healthz"}
+
+	invoker := NewWebhookInvoker(newTestTokenSecretGetter())
+	if _, err := invoker.CallCreateLoadBalancer(driver, &webhooks.CreateLoadBalancerRequest{}); err == nil {
+		t.Errorf("expect error when sending token over http")
+	}
+	if err := invoker.ProbeDriver(driver); err == nil {
+		t.Errorf("expect error when probing with token over http")
+	}
+	if atomic.LoadInt32(&called) == 1 {
+		t.Errorf("expect driver not to be called")
+	}
+}
+
+func TestGRPCInvokerRefusesTokenWithoutTLS(t *testing.T) {
+	_, _, addr, stop := startFakeDriver(t)
+	defer stop()
+	driver := newGRPCDriver(addr)
+	driver.Spec.ClientConfig = &lbcfapi.DriverClientConfig{TokenSecret: "driver-token"}
+
+	invoker := newGRPCInvoker(newCredentialStore(newTestTokenSecretGetter()), newCircuitBreaker())
+	if _, err := invoker.getConn(driver); err == nil {
+		t.Errorf("expect error when sending token without TLS")
+	}
+	if len(invoker.conns) != 0 {
+		t.Errorf("expect no connection, got %d", len(invoker.conns))
+	}
+}
@@ -0,0 +1,497 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks/driverpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"k8s.io/klog"
+)
+
+// isGRPCDriver returns true if webhooks of driver should be called via gRPC
+func isGRPCDriver(driver *lbcfapi.LoadBalancerDriver) bool {
+	return driver.Spec.DriverType == string(lbcfapi.GRPCDriver)
+}
+
+// grpcInvoker calls drivers of type GRPC.
+//
+// Connections are established lazily and shared by all calls to the same driver,
+// a connection is re-established if the url of the driver is changed or the Secrets referenced by the driver are rotated.
+// The replaced connection is closed after all calls using it are finished.
+type grpcInvoker struct {
+	sync.Mutex
+	credentials *credentialStore
+	breaker     *circuitBreaker
+	conns       map[string]*grpcConn
+}
+
+type grpcConn struct {
+	*grpc.ClientConn
+	url               string
+	credentialVersion string
+
+	// inflight and replaced are guarded by grpcInvoker
+	inflight int
+	replaced bool
+}
+
+func newGRPCInvoker(credentials *credentialStore, breaker *circuitBreaker) *grpcInvoker {
+	return &grpcInvoker{
+		credentials: credentials,
+		breaker:     breaker,
+		conns:       make(map[string]*grpcConn),
+	}
+}
+
+// getConn returns the connection to driver, release must be called after the connection is no longer used
+func (g *grpcInvoker) getConn(driver *lbcfapi.LoadBalancerDriver) (*grpcConn, error) {
+	cred, err := g.credentials.get(driver)
+	if err != nil {
+		return nil, err
+	}
+	key := NamespacedNameKeyFunc(driver.Namespace, driver.Name)
+
+	g.Lock()
+	defer g.Unlock()
+	if conn, ok := g.conns[key]; ok {
+		if conn.url == driver.Spec.Url && conn.credentialVersion == cred.version {
+			conn.inflight++
+			return conn, nil
+		}
+		// calls using the old connection are not interrupted
+		conn.replaced = true
+		if conn.inflight == 0 {
+			conn.Close()
+		}
+		delete(g.conns, key)
+	}
+	if err := checkTokenTransport(cred, cred.tlsConfig != nil); err != nil {
+		return nil, err
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if cred.tlsConfig != nil {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(cred.tlsConfig))}
+	}
+	if cred.token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken{token: cred.token}))
+	}
+	conn, err := grpc.Dial(driver.Spec.Url, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s failed: %v", driver.Spec.Url, err)
+	}
+	c := &grpcConn{
+		ClientConn:        conn,
+		url:               driver.Spec.Url,
+		credentialVersion: cred.version,
+		inflight:          1,
+	}
+	g.conns[key] = c
+	return c, nil
+}
+
+// release closes conn if it is replaced and no longer used by any call
+func (g *grpcInvoker) release(conn *grpcConn) {
+	g.Lock()
+	defer g.Unlock()
+	conn.inflight--
+	if conn.replaced && conn.inflight == 0 {
+		conn.Close()
+	}
+}
+
+// probe checks the health of driver with the standard gRPC health checking protocol
+func (g *grpcInvoker) probe(driver *lbcfapi.LoadBalancerDriver, timeout time.Duration) error {
+	conn, err := g.getConn(driver)
+	if err != nil {
+		return err
+	}
+	defer g.release(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	rsp, err := healthpb.NewHealthClient(conn.ClientConn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return fmt.Errorf("grpc err: %v", err)
+	}
+	if rsp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("serving status: %s", rsp.GetStatus())
+	}
+	return nil
+}
+
+// call invokes rpc on driver with the timeout configured for webHookName
+func (g *grpcInvoker) call(driver *lbcfapi.LoadBalancerDriver, webHookName string,
+	rpc func(ctx context.Context, client driverpb.LoadBalancerDriverClient) error) error {
+	conn, err := g.getConn(driver)
+	if err != nil {
+		klog.Errorf("callgrpc failed: %v. driver: %s, webhookName: %s", err, driver.Name, webHookName)
+		return err
+	}
+	defer g.release(conn)
+	client := driverpb.NewLoadBalancerDriverClient(conn.ClientConn)
+	ctx := context.Background()
+	if timeout := getWebhookTimeout(driver, webHookName); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	driverKey := NamespacedNameKeyFunc(driver.Namespace, driver.Name)
+	if err := g.breaker.allow(driverKey); err != nil {
+		return err
+	}
+	klog.V(3).Infof("callgrpc, driver: %s, target: %s, method: %s", driver.Name, driver.Spec.Url, webHookName)
+	err = rpc(ctx, client)
+	g.breaker.done(driverKey, err)
+	if err != nil {
+		e := fmt.Errorf("grpc err: %v", err)
+		klog.Errorf("callgrpc failed: %v. driver: %s, webhookName: %s", e, driver.Name, webHookName)
+		return e
+	}
+	return nil
+}
+
+func (g *grpcInvoker) callValidateLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.ValidateLoadBalancerRequest) (*webhooks.ValidateLoadBalancerResponse, error) {
+	var pbRsp *driverpb.ValidateLoadBalancerResponse
+	err := g.call(driver, webhooks.ValidateLoadBalancer, func(ctx context.Context, client driverpb.LoadBalancerDriverClient) (err error) {
+		pbRsp, err = client.ValidateLoadBalancer(ctx, &driverpb.ValidateLoadBalancerRequest{
+			LbSpec:        req.LBSpec,
+			Operation:     string(req.Operation),
+			Attributes:    req.Attributes,
+			OldAttributes: req.OldAttributes,
+		})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &webhooks.ValidateLoadBalancerResponse{
+		ResponseForNoRetryHooks: noRetryResponseFromPB(pbRsp.GetResult()),
+	}, nil
+}
+
+func (g *grpcInvoker) callCreateLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.CreateLoadBalancerRequest) (*webhooks.CreateLoadBalancerResponse, error) {
+	var pbRsp *driverpb.CreateLoadBalancerResponse
+	err := g.call(driver, webhooks.CreateLoadBalancer, func(ctx context.Context, client driverpb.LoadBalancerDriverClient) (err error) {
+		pbRsp, err = client.CreateLoadBalancer(ctx, &driverpb.CreateLoadBalancerRequest{
+			Retry:      retryRequestToPB(req.RequestForRetryHooks),
+			LbSpec:     req.LBSpec,
+			Attributes: req.Attributes,
+		})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &webhooks.CreateLoadBalancerResponse{
+		ResponseForFailRetryHooks: retryResponseFromPB(pbRsp.GetResult()),
+		LBInfo:                    pbRsp.GetLbInfo(),
+	}, nil
+}
+
+func (g *grpcInvoker) callImportLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.ImportLoadBalancerRequest) (*webhooks.ImportLoadBalancerResponse, error) {
+	var pbRsp *driverpb.ImportLoadBalancerResponse
+	err := g.call(driver, webhooks.ImportLoadBalancer, func(ctx context.Context, client driverpb.LoadBalancerDriverClient) (err error) {
+		pbRsp, err = client.ImportLoadBalancer(ctx, &driverpb.ImportLoadBalancerRequest{
+			Retry:      retryRequestToPB(req.RequestForRetryHooks),
+			LbSpec:     req.LBSpec,
+			Attributes: req.Attributes,
+		})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &webhooks.ImportLoadBalancerResponse{
+		ResponseForFailRetryHooks: retryResponseFromPB(pbRsp.GetResult()),
+		LBInfo:                    pbRsp.GetLbInfo(),
+	}, nil
+}
+
+func (g *grpcInvoker) callEnsureLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.EnsureLoadBalancerRequest) (*webhooks.EnsureLoadBalancerResponse, error) {
+	var pbRsp *driverpb.EnsureLoadBalancerResponse
+	err := g.call(driver, webhooks.EnsureLoadBalancer, func(ctx context.Context, client driverpb.LoadBalancerDriverClient) (err error) {
+		pbRsp, err = client.EnsureLoadBalancer(ctx, &driverpb.EnsureLoadBalancerRequest{
+			Retry:      retryRequestToPB(req.RequestForRetryHooks),
+			LbInfo:     req.LBInfo,
+			Attributes: req.Attributes,
+		})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &webhooks.EnsureLoadBalancerResponse{
+		ResponseForFailRetryHooks: retryResponseFromPB(pbRsp.GetResult()),
+	}, nil
+}
+
+func (g *grpcInvoker) callDeleteLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.DeleteLoadBalancerRequest) (*webhooks.DeleteLoadBalancerResponse, error) {
+	var pbRsp *driverpb.DeleteLoadBalancerResponse
+	err := g.call(driver, webhooks.DeleteLoadBalancer, func(ctx context.Context, client driverpb.LoadBalancerDriverClient) (err error) {
+		pbRsp, err = client.DeleteLoadBalancer(ctx, &driverpb.DeleteLoadBalancerRequest{
+			Retry:      retryRequestToPB(req.RequestForRetryHooks),
+			LbInfo:     req.LBInfo,
+			Attributes: req.Attributes,
+		})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &webhooks.DeleteLoadBalancerResponse{
+		ResponseForFailRetryHooks: retryResponseFromPB(pbRsp.GetResult()),
+	}, nil
+}
+
+func (g *grpcInvoker) callValidateBackend(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.ValidateBackendRequest) (*webhooks.ValidateBackendResponse, error) {
+	var pbRsp *driverpb.ValidateBackendResponse
+	err := g.call(driver, webhooks.ValidateBackend, func(ctx context.Context, client driverpb.LoadBalancerDriverClient) (err error) {
+		pbRsp, err = client.ValidateBackend(ctx, &driverpb.ValidateBackendRequest{
+			BackendType:   req.BackendType,
+			LbInfo:        req.LBInfo,
+			Operation:     string(req.Operation),
+			Parameters:    req.Parameters,
+			OldParameters: req.OldParameters,
+		})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &webhooks.ValidateBackendResponse{
+		ResponseForNoRetryHooks: noRetryResponseFromPB(pbRsp.GetResult()),
+	}, nil
+}
+
+func (g *grpcInvoker) callGenerateBackendAddr(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.GenerateBackendAddrRequest) (*webhooks.GenerateBackendAddrResponse, error) {
+	pbReq, err := generateBackendAddrRequestToPB(req)
+	if err != nil {
+		klog.Errorf("callgrpc failed: %v. driver: %s, webhookName: %s", err, driver.Name, webhooks.GenerateBackendAddr)
+		return nil, err
+	}
+	var pbRsp *driverpb.GenerateBackendAddrResponse
+	err = g.call(driver, webhooks.GenerateBackendAddr, func(ctx context.Context, client driverpb.LoadBalancerDriverClient) (err error) {
+		pbRsp, err = client.GenerateBackendAddr(ctx, pbReq)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &webhooks.GenerateBackendAddrResponse{
+		ResponseForFailRetryHooks: retryResponseFromPB(pbRsp.GetResult()),
+		BackendAddr:               pbRsp.GetBackendAddr(),
+	}, nil
+}
+
+func (g *grpcInvoker) callEnsureBackend(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.BackendOperationRequest) (*webhooks.BackendOperationResponse, error) {
+	var pbRsp *driverpb.BackendOperationResponse
+	err := g.call(driver, webhooks.EnsureBackend, func(ctx context.Context, client driverpb.LoadBalancerDriverClient) (err error) {
+		pbRsp, err = client.EnsureBackend(ctx, backendOperationRequestToPB(req))
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return backendOperationResponseFromPB(pbRsp), nil
+}
+
+func (g *grpcInvoker) callDeregisterBackend(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.BackendOperationRequest) (*webhooks.BackendOperationResponse, error) {
+	var pbRsp *driverpb.BackendOperationResponse
+	err := g.call(driver, webhooks.DeregBackend, func(ctx context.Context, client driverpb.LoadBalancerDriverClient) (err error) {
+		pbRsp, err = client.DeregisterBackend(ctx, backendOperationRequestToPB(req))
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return backendOperationResponseFromPB(pbRsp), nil
+}
+
+func (g *grpcInvoker) callBatchEnsureBackend(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.BatchBackendOperationRequest) (*webhooks.BatchBackendOperationResponse, error) {
+	var pbRsp *driverpb.BatchBackendOperationResponse
+	err := g.call(driver, webhooks.BatchEnsureBackend, func(ctx context.Context, client driverpb.LoadBalancerDriverClient) (err error) {
+		pbRsp, err = client.BatchEnsureBackend(ctx, batchBackendOperationRequestToPB(req))
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batchBackendOperationResponseFromPB(pbRsp), nil
+}
+
+func (g *grpcInvoker) callBatchDeregisterBackend(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.BatchBackendOperationRequest) (*webhooks.BatchBackendOperationResponse, error) {
+	var pbRsp *driverpb.BatchBackendOperationResponse
+	err := g.call(driver, webhooks.BatchDeregBackend, func(ctx context.Context, client driverpb.LoadBalancerDriverClient) (err error) {
+		pbRsp, err = client.BatchDeregisterBackend(ctx, batchBackendOperationRequestToPB(req))
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batchBackendOperationResponseFromPB(pbRsp), nil
+}
+
+func retryRequestToPB(req webhooks.RequestForRetryHooks) *driverpb.RequestForRetryHooks {
+	return &driverpb.RequestForRetryHooks{
+		RecordId: req.RecordID,
+		RetryId:  req.RetryID,
+	}
+}
+
+func retryResponseFromPB(rsp *driverpb.ResponseForFailRetryHooks) webhooks.ResponseForFailRetryHooks {
+	return webhooks.ResponseForFailRetryHooks{
+		Status:                 rsp.GetStatus(),
+		Msg:                    rsp.GetMsg(),
+		MinRetryDelayInSeconds: rsp.GetMinRetryDelayInSeconds(),
+	}
+}
+
+func noRetryResponseFromPB(rsp *driverpb.ResponseForNoRetryHooks) webhooks.ResponseForNoRetryHooks {
+	return webhooks.ResponseForNoRetryHooks{
+		Succ: rsp.GetSucc(),
+		Msg:  rsp.GetMsg(),
+	}
+}
+
+func portSelectorToPB(port lbcfapi.PortSelector) *driverpb.PortSelector {
+	return &driverpb.PortSelector{
+		PortNumber: port.PortNumber,
+		Protocol:   port.Protocol,
+		PortName:   port.PortName,
+	}
+}
+
+func generateBackendAddrRequestToPB(req *webhooks.GenerateBackendAddrRequest) (*driverpb.GenerateBackendAddrRequest, error) {
+	pbReq := &driverpb.GenerateBackendAddrRequest{
+		Retry:        retryRequestToPB(req.RequestForRetryHooks),
+		LbInfo:       req.LBInfo,
+		LbAttributes: req.LBAttributes,
+		Parameters:   req.Parameters,
+	}
+	if req.PodBackend != nil {
+		pod, err := json.Marshal(req.PodBackend.Pod)
+		if err != nil {
+			return nil, fmt.Errorf("encode pod failed: %v", err)
+		}
+		pbReq.PodBackend = &driverpb.PodBackendInGenerateAddrRequest{
+			Pod:  pod,
+			Port: portSelectorToPB(req.PodBackend.Port),
+		}
+	}
+	if req.ServiceBackend != nil {
+		svc, err := json.Marshal(req.ServiceBackend.Service)
+		if err != nil {
+			return nil, fmt.Errorf("encode service failed: %v", err)
+		}
+		var addrs []*driverpb.NodeAddress
+		for _, addr := range req.ServiceBackend.NodeAddresses {
+			addrs = append(addrs, &driverpb.NodeAddress{
+				Type:    string(addr.Type),
+				Address: addr.Address,
+			})
+		}
+		pbReq.ServiceBackend = &driverpb.ServiceBackendInGenerateAddrRequest{
+			Service:       svc,
+			Port:          portSelectorToPB(req.ServiceBackend.Port),
+			NodeName:      req.ServiceBackend.NodeName,
+			NodeAddresses: addrs,
+		}
+	}
+	if req.NodeBackend != nil {
+		node, err := json.Marshal(req.NodeBackend.Node)
+		if err != nil {
+			return nil, fmt.Errorf("encode node failed: %v", err)
+		}
+		pbReq.NodeBackend = &driverpb.NodeBackendInGenerateAddrRequest{
+			Node: node,
+			Port: portSelectorToPB(req.NodeBackend.Port),
+		}
+	}
+	return pbReq, nil
+}
+
+func backendOperationRequestToPB(req *webhooks.BackendOperationRequest) *driverpb.BackendOperationRequest {
+	return &driverpb.BackendOperationRequest{
+		Retry:        retryRequestToPB(req.RequestForRetryHooks),
+		LbInfo:       req.LBInfo,
+		BackendAddr:  req.BackendAddr,
+		Parameters:   req.Parameters,
+		InjectedInfo: req.InjectedInfo,
+		Weight:       req.Weight,
+	}
+}
+
+func backendOperationResponseFromPB(rsp *driverpb.BackendOperationResponse) *webhooks.BackendOperationResponse {
+	return &webhooks.BackendOperationResponse{
+		ResponseForFailRetryHooks: retryResponseFromPB(rsp.GetResult()),
+		InjectedInfo:              rsp.GetInjectedInfo(),
+	}
+}
+
+func batchBackendOperationRequestToPB(req *webhooks.BatchBackendOperationRequest) *driverpb.BatchBackendOperationRequest {
+	pbReq := &driverpb.BatchBackendOperationRequest{
+		Retry:  retryRequestToPB(req.RequestForRetryHooks),
+		LbInfo: req.LBInfo,
+	}
+	for _, item := range req.Backends {
+		pbReq.Backends = append(pbReq.Backends, &driverpb.BatchBackendOperationItem{
+			RecordId:     item.RecordID,
+			BackendAddr:  item.BackendAddr,
+			Parameters:   item.Parameters,
+			InjectedInfo: item.InjectedInfo,
+			Weight:       item.Weight,
+		})
+	}
+	return pbReq
+}
+
+func batchBackendOperationResponseFromPB(rsp *driverpb.BatchBackendOperationResponse) *webhooks.BatchBackendOperationResponse {
+	result := &webhooks.BatchBackendOperationResponse{
+		ResponseForFailRetryHooks: retryResponseFromPB(rsp.GetResult()),
+	}
+	for _, r := range rsp.GetResults() {
+		result.Results = append(result.Results, webhooks.BatchBackendOperationResult{
+			ResponseForFailRetryHooks: retryResponseFromPB(r.GetResult()),
+			RecordID:                  r.GetRecordId(),
+			InjectedInfo:              r.GetInjectedInfo(),
+		})
+	}
+	return result
+}
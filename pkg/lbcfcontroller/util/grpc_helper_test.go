@@ -0,0 +1,414 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks/driverpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeDriverServer records requests it receives and answers with canned responses
+type fakeDriverServer struct {
+	driverpb.UnimplementedLoadBalancerDriverServer
+
+	sync.Mutex
+	createReq *driverpb.CreateLoadBalancerRequest
+	ensureReq *driverpb.BackendOperationRequest
+	batchReq  *driverpb.BatchBackendOperationRequest
+	fail      bool
+
+	// if block is set, CreateLoadBalancer signals started and waits until block is closed
+	started chan struct{}
+	block   chan struct{}
+}
+
+func (s *fakeDriverServer) CreateLoadBalancer(ctx context.Context,
+	req *driverpb.CreateLoadBalancerRequest) (*driverpb.CreateLoadBalancerResponse, error) {
+	if s.block != nil {
+		s.started <- struct{}{}
+		<-s.block
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.createReq = req
+	if s.fail {
+		return nil, status.Error(codes.Unavailable, "driver down")
+	}
+	return &driverpb.CreateLoadBalancerResponse{
+		Result: &driverpb.ResponseForFailRetryHooks{Status: webhooks.StatusSucc},
+		LbInfo: map[string]string{"lbID": "lb-1"},
+	}, nil
+}
+
+func (s *fakeDriverServer) EnsureBackend(ctx context.Context,
+	req *driverpb.BackendOperationRequest) (*driverpb.BackendOperationResponse, error) {
+	s.Lock()
+	defer s.Unlock()
+	s.ensureReq = req
+	return &driverpb.BackendOperationResponse{
+		Result: &driverpb.ResponseForFailRetryHooks{
+			Status:                 webhooks.StatusRunning,
+			Msg:                    "in progress",
+			MinRetryDelayInSeconds: 5,
+		},
+		InjectedInfo: map[string]string{"rsID": "rs-1"},
+	}, nil
+}
+
+func (s *fakeDriverServer) BatchEnsureBackend(ctx context.Context,
+	req *driverpb.BatchBackendOperationRequest) (*driverpb.BatchBackendOperationResponse, error) {
+	s.Lock()
+	defer s.Unlock()
+	s.batchReq = req
+	rsp := &driverpb.BatchBackendOperationResponse{
+		Result: &driverpb.ResponseForFailRetryHooks{Status: webhooks.StatusSucc},
+	}
+	for _, item := range req.GetBackends() {
+		rsp.Results = append(rsp.Results, &driverpb.BatchBackendOperationResult{
+			RecordId:     item.GetRecordId(),
+			Result:       &driverpb.ResponseForFailRetryHooks{Status: webhooks.StatusSucc},
+			InjectedInfo: map[string]string{"addr": item.GetBackendAddr()},
+		})
+	}
+	return rsp, nil
+}
+
+func (s *fakeDriverServer) ValidateBackend(ctx context.Context,
+	req *driverpb.ValidateBackendRequest) (*driverpb.ValidateBackendResponse, error) {
+	return &driverpb.ValidateBackendResponse{
+		Result: &driverpb.ResponseForNoRetryHooks{Succ: false, Msg: "invalid port"},
+	}, nil
+}
+
+func (s *fakeDriverServer) setFail(fail bool) {
+	s.Lock()
+	defer s.Unlock()
+	s.fail = fail
+}
+
+// startFakeDriver starts an in-process gRPC driver listening on a random local port
+func startFakeDriver(t *testing.T) (*fakeDriverServer, *health.Server, string, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	srv := grpc.NewServer()
+	fake := &fakeDriverServer{}
+	driverpb.RegisterLoadBalancerDriverServer(srv, fake)
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	go srv.Serve(lis)
+	return fake, hs, lis.Addr().String(), srv.Stop
+}
+
+func newGRPCDriver(url string) *lbcfapi.LoadBalancerDriver {
+	return &lbcfapi.LoadBalancerDriver{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lbcf-grpc-driver",
+			Namespace: "kube-system",
+		},
+		Spec: lbcfapi.LoadBalancerDriverSpec{
+			DriverType: string(lbcfapi.GRPCDriver),
+			Url:        url,
+		},
+	}
+}
+
+func TestGRPCInvokerCallCreateLoadBalancer(t *testing.T) {
+	fake, _, addr, stop := startFakeDriver(t)
+	defer stop()
+	invoker := newGRPCInvoker(newCredentialStore(nil), newCircuitBreaker())
+	driver := newGRPCDriver(addr)
+
+	rsp, err := invoker.callCreateLoadBalancer(driver, &webhooks.CreateLoadBalancerRequest{
+		RequestForRetryHooks: webhooks.RequestForRetryHooks{RecordID: "record", RetryID: "retry"},
+		LBSpec:               map[string]string{"vpcID": "vpc-1"},
+		Attributes:           map[string]string{"a": "b"},
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if rsp.Status != webhooks.StatusSucc || rsp.LBInfo["lbID"] != "lb-1" {
+		t.Errorf("unexpected response: %+v", rsp)
+	}
+	fake.Lock()
+	defer fake.Unlock()
+	if fake.createReq.GetRetry().GetRecordId() != "record" || fake.createReq.GetRetry().GetRetryId() != "retry" {
+		t.Errorf("retry info not sent: %+v", fake.createReq.GetRetry())
+	}
+	if fake.createReq.GetLbSpec()["vpcID"] != "vpc-1" || fake.createReq.GetAttributes()["a"] != "b" {
+		t.Errorf("unexpected request: %+v", fake.createReq)
+	}
+}
+
+func TestGRPCInvokerCallEnsureBackend(t *testing.T) {
+	fake, _, addr, stop := startFakeDriver(t)
+	defer stop()
+	invoker := newGRPCInvoker(newCredentialStore(nil), newCircuitBreaker())
+	driver := newGRPCDriver(addr)
+
+	weight := int32(10)
+	rsp, err := invoker.callEnsureBackend(driver, &webhooks.BackendOperationRequest{
+		LBInfo:      map[string]string{"lbID": "lb-1"},
+		BackendAddr: "1.1.1.1:80",
+		Weight:      &weight,
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if rsp.Status != webhooks.StatusRunning || rsp.Msg != "in progress" || rsp.MinRetryDelayInSeconds != 5 {
+		t.Errorf("unexpected response: %+v", rsp.ResponseForFailRetryHooks)
+	}
+	if rsp.InjectedInfo["rsID"] != "rs-1" {
+		t.Errorf("unexpected injectedInfo: %v", rsp.InjectedInfo)
+	}
+	fake.Lock()
+	defer fake.Unlock()
+	if fake.ensureReq.GetBackendAddr() != "1.1.1.1:80" || fake.ensureReq.GetWeight() != 10 {
+		t.Errorf("unexpected request: %+v", fake.ensureReq)
+	}
+}
+
+func TestGRPCInvokerCallBatchEnsureBackend(t *testing.T) {
+	_, _, addr, stop := startFakeDriver(t)
+	defer stop()
+	invoker := newGRPCInvoker(newCredentialStore(nil), newCircuitBreaker())
+	driver := newGRPCDriver(addr)
+
+	rsp, err := invoker.callBatchEnsureBackend(driver, &webhooks.BatchBackendOperationRequest{
+		LBInfo: map[string]string{"lbID": "lb-1"},
+		Backends: []webhooks.BatchBackendOperationItem{
+			{RecordID: "r1", BackendAddr: "1.1.1.1:80"},
+			{RecordID: "r2", BackendAddr: "2.2.2.2:80"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if len(rsp.Results) != 2 {
+		t.Fatalf("expect 2 results, got %d", len(rsp.Results))
+	}
+	for i, expect := range []string{"1.1.1.1:80", "2.2.2.2:80"} {
+		if rsp.Results[i].InjectedInfo["addr"] != expect {
+			t.Errorf("result %d: expect addr %s, got %v", i, expect, rsp.Results[i].InjectedInfo)
+		}
+	}
+}
+
+func TestGRPCInvokerCallValidateBackend(t *testing.T) {
+	_, _, addr, stop := startFakeDriver(t)
+	defer stop()
+	invoker := newGRPCInvoker(newCredentialStore(nil), newCircuitBreaker())
+
+	rsp, err := invoker.callValidateBackend(newGRPCDriver(addr), &webhooks.ValidateBackendRequest{})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if rsp.Succ || rsp.Msg != "invalid port" || rsp.GetStatus() != webhooks.StatusFail {
+		t.Errorf("unexpected response: %+v", rsp)
+	}
+}
+
+func TestGRPCInvokerUnimplemented(t *testing.T) {
+	_, _, addr, stop := startFakeDriver(t)
+	defer stop()
+	invoker := newGRPCInvoker(newCredentialStore(nil), newCircuitBreaker())
+
+	if _, err := invoker.callDeleteLoadBalancer(newGRPCDriver(addr), &webhooks.DeleteLoadBalancerRequest{}); err == nil {
+		t.Fatalf("expect error for unimplemented rpc")
+	}
+}
+
+func TestGRPCInvokerReusesConnection(t *testing.T) {
+	_, _, addr1, stop1 := startFakeDriver(t)
+	defer stop1()
+	_, _, addr2, stop2 := startFakeDriver(t)
+	defer stop2()
+	invoker := newGRPCInvoker(newCredentialStore(nil), newCircuitBreaker())
+	driver := newGRPCDriver(addr1)
+
+	conn1, err := invoker.getConn(driver)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	conn2, err := invoker.getConn(driver)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if conn1 != conn2 {
+		t.Errorf("expect connection to be reused")
+	}
+	invoker.release(conn2)
+
+	driver.Spec.Url = addr2
+	conn3, err := invoker.getConn(driver)
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	defer invoker.release(conn3)
+	if conn3 == conn1 {
+		t.Errorf("expect a new connection after url changed")
+	}
+	if len(invoker.conns) != 1 {
+		t.Errorf("expect 1 cached connection, got %d", len(invoker.conns))
+	}
+
+	// conn1 is still used by a call, it must not be closed until released
+	if conn1.GetState() == connectivity.Shutdown {
+		t.Fatalf("expect replaced connection to be kept while in use")
+	}
+	invoker.release(conn1)
+	if conn1.GetState() != connectivity.Shutdown {
+		t.Errorf("expect replaced connection to be closed after released, got %s", conn1.GetState())
+	}
+	if conn3.GetState() == connectivity.Shutdown {
+		t.Errorf("expect current connection to be kept")
+	}
+}
+
+func TestGRPCInvokerReplacesConnectionDuringCalls(t *testing.T) {
+	fake, _, addr1, stop1 := startFakeDriver(t)
+	defer stop1()
+	_, _, addr2, stop2 := startFakeDriver(t)
+	defer stop2()
+	invoker := newGRPCInvoker(newCredentialStore(nil), newCircuitBreaker())
+	driver := newGRPCDriver(addr1)
+
+	fake.started, fake.block = make(chan struct{}), make(chan struct{})
+	errCh := make(chan error)
+	go func() {
+		_, err := invoker.callCreateLoadBalancer(driver.DeepCopy(), &webhooks.CreateLoadBalancerRequest{})
+		errCh <- err
+	}()
+	<-fake.started
+
+	updated := driver.DeepCopy()
+	updated.Spec.Url = addr2
+	if _, err := invoker.callCreateLoadBalancer(updated, &webhooks.CreateLoadBalancerRequest{}); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	close(fake.block)
+	if err := <-errCh; err != nil {
+		t.Errorf("expect in-flight call to finish on the replaced connection, got %v", err)
+	}
+}
+
+func TestGRPCInvokerOpensCircuit(t *testing.T) {
+	fake, _, addr, stop := startFakeDriver(t)
+	defer stop()
+	fake.setFail(true)
+	invoker := newGRPCInvoker(newCredentialStore(nil), newCircuitBreaker())
+	driver := newGRPCDriver(addr)
+	driverKey := NamespacedNameKeyFunc(driver.Namespace, driver.Name)
+
+	for i := 0; i < 100 && !invoker.breaker.isOpen(driverKey); i++ {
+		if _, err := invoker.callCreateLoadBalancer(driver, &webhooks.CreateLoadBalancerRequest{}); err == nil {
+			t.Fatalf("expect error from failing driver")
+		}
+	}
+	if !invoker.breaker.isOpen(driverKey) {
+		t.Fatalf("expect circuit to be open after consecutive failures")
+	}
+	_, err := invoker.callCreateLoadBalancer(driver, &webhooks.CreateLoadBalancerRequest{})
+	if _, ok := err.(*CircuitOpenError); !ok {
+		t.Errorf("expect CircuitOpenError, got %v", err)
+	}
+}
+
+func TestGRPCInvokerProbe(t *testing.T) {
+	_, hs, addr, stop := startFakeDriver(t)
+	defer stop()
+	invoker := newGRPCInvoker(newCredentialStore(nil), newCircuitBreaker())
+	driver := newGRPCDriver(addr)
+
+	if err := invoker.probe(driver, DefaultHealthCheckTimeout); err != nil {
+		t.Errorf("expect serving driver to pass probe, got %v", err)
+	}
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	if err := invoker.probe(driver, DefaultHealthCheckTimeout); err == nil {
+		t.Errorf("expect not serving driver to fail probe")
+	}
+}
+
+func TestWebhookInvokerDispatch(t *testing.T) {
+	fake, _, addr, stop := startFakeDriver(t)
+	defer stop()
+
+	var httpCalled int32
+	httpDriver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.StoreInt32(&httpCalled, 1)
+		if r.URL.Path != "/"+webhooks.CreateLoadBalancer {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(webhooks.CreateLoadBalancerResponse{
+			ResponseForFailRetryHooks: webhooks.ResponseForFailRetryHooks{Status: webhooks.StatusSucc},
+			LBInfo:                    map[string]string{"lbID": "lb-http"},
+		})
+	}))
+	defer httpDriver.Close()
+
+	invoker := NewWebhookInvoker(nil)
+
+	rsp, err := invoker.CallCreateLoadBalancer(newGRPCDriver(addr), &webhooks.CreateLoadBalancerRequest{})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if rsp.LBInfo["lbID"] != "lb-1" {
+		t.Errorf("expect response from gRPC driver, got %+v", rsp)
+	}
+	fake.Lock()
+	if fake.createReq == nil {
+		t.Errorf("expect gRPC driver to be called")
+	}
+	fake.Unlock()
+	if atomic.LoadInt32(&httpCalled) == 1 {
+		t.Errorf("expect webhook driver not to be called")
+	}
+
+	webhookDriver := newGRPCDriver(httpDriver.URL)
+	webhookDriver.Spec.DriverType = string(lbcfapi.WebhookDriver)
+	webhookDriver.Spec.Webhooks = []lbcfapi.WebhookConfig{
+		{Name: webhooks.CreateLoadBalancer, Timeout: lbcfapi.Duration{Duration: 10 * time.Second}},
+	}
+	rsp, err = invoker.CallCreateLoadBalancer(webhookDriver, &webhooks.CreateLoadBalancerRequest{})
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if atomic.LoadInt32(&httpCalled) != 1 || rsp.LBInfo["lbID"] != "lb-http" {
+		t.Errorf("expect response from webhook driver, got %+v", rsp)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// InFlightRetryDelay is the minimum delay before retrying an operation that is rejected by InFlightLimiter,
+// the delay is jittered, and grows with the rate limiter of the queue if the operation is rejected repeatedly
+const InFlightRetryDelay = time.Second
+
+// NewInFlightLimiter creates a new InFlightLimiter, max <= 0 means unlimited
+func NewInFlightLimiter(max int) *InFlightLimiter {
+	return &InFlightLimiter{
+		max:      max,
+		inFlight: make(map[string]int),
+	}
+}
+
+// InFlightLimiter limits the number of concurrent operations on each driver,
+// so that a slow driver can not occupy all workers
+type InFlightLimiter struct {
+	sync.Mutex
+	max      int
+	inFlight map[string]int
+}
+
+// TryAcquire returns false if the number of in-flight operations on driver reaches max,
+// Release must be called if true is returned
+func (l *InFlightLimiter) TryAcquire(driver string) bool {
+	if l.max <= 0 {
+		return true
+	}
+	l.Lock()
+	defer l.Unlock()
+	if l.inFlight[driver] >= l.max {
+		return false
+	}
+	l.inFlight[driver]++
+	return true
+}
+
+// Release indicates an operation on driver is finished
+func (l *InFlightLimiter) Release(driver string) {
+	if l.max <= 0 {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	l.inFlight[driver]--
+	if l.inFlight[driver] <= 0 {
+		delete(l.inFlight, driver)
+	}
+}
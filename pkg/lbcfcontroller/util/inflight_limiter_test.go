@@ -0,0 +1,102 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestInFlightLimiter(t *testing.T) {
+	l := NewInFlightLimiter(2)
+	if !l.TryAcquire("driver-a") || !l.TryAcquire("driver-a") {
+		t.Fatalf("expect 2 operations to be allowed")
+	}
+	if l.TryAcquire("driver-a") {
+		t.Errorf("expect the 3rd operation to be rejected")
+	}
+	if !l.TryAcquire("driver-b") {
+		t.Errorf("expect operations on other drivers not to be limited")
+	}
+
+	l.Release("driver-a")
+	if !l.TryAcquire("driver-a") {
+		t.Errorf("expect operation to be allowed after release")
+	}
+	l.Release("driver-a")
+	l.Release("driver-a")
+	l.Release("driver-b")
+	if len(l.inFlight) != 0 {
+		t.Errorf("expect drivers without in-flight operations to be removed, got %v", l.inFlight)
+	}
+}
+
+func TestInFlightLimiterUnlimited(t *testing.T) {
+	for _, max := range []int{0, -1} {
+		l := NewInFlightLimiter(max)
+		for i := 0; i < 100; i++ {
+			if !l.TryAcquire("driver") {
+				t.Fatalf("max %d: expect unlimited operations to be allowed", max)
+			}
+		}
+		l.Release("driver")
+		if len(l.inFlight) != 0 {
+			t.Errorf("max %d: expect nothing to be tracked, got %v", max, l.inFlight)
+		}
+	}
+}
+
+func TestInFlightLimiterConcurrent(t *testing.T) {
+	const max = 5
+	l := NewInFlightLimiter(max)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		inFlight int
+		peak     int
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if !l.TryAcquire("driver") {
+					continue
+				}
+				mu.Lock()
+				inFlight++
+				if inFlight > peak {
+					peak = inFlight
+				}
+				mu.Unlock()
+
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+				l.Release("driver")
+			}
+		}()
+	}
+	wg.Wait()
+	if peak > max {
+		t.Errorf("expect at most %d in-flight operations, got %d", max, peak)
+	}
+	if len(l.inFlight) != 0 {
+		t.Errorf("expect all operations to be released, got %v", l.inFlight)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
+)
+
+func newTestLeaseLock(client *fake.Clientset, identity string) *LeaseLock {
+	return &LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "lbcf-controller"},
+		Client:    client.CoordinationV1beta1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity:      identity,
+			EventRecorder: record.NewFakeRecorder(10),
+		},
+	}
+}
+
+func newTestElectionRecord(identity string, transitions int) resourcelock.LeaderElectionRecord {
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	return resourcelock.LeaderElectionRecord{
+		HolderIdentity:       identity,
+		LeaseDurationSeconds: 15,
+		AcquireTime:          now,
+		RenewTime:            now,
+		LeaderTransitions:    transitions,
+	}
+}
+
+func TestLeaseLockCreateAndGet(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	lock := newTestLeaseLock(client, "replica-1")
+
+	if _, err := lock.Get(); !errors.IsNotFound(err) {
+		t.Fatalf("expect NotFound before the Lease is created, got %v", err)
+	}
+	ler := newTestElectionRecord("replica-1", 0)
+	if err := lock.Create(ler); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	lease, err := client.CoordinationV1beta1().Leases("kube-system").Get("lbcf-controller", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expect Lease to be created, got %v", err)
+	}
+	if *lease.Spec.HolderIdentity != "replica-1" || *lease.Spec.LeaseDurationSeconds != 15 {
+		t.Errorf("unexpected Lease spec: %+v", lease.Spec)
+	}
+
+	// another replica reads the record written by the leader
+	got, err := newTestLeaseLock(client, "replica-2").Get()
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if !reflect.DeepEqual(*got, ler) {
+		t.Errorf("expect record %+v, got %+v", ler, *got)
+	}
+}
+
+func TestLeaseLockUpdate(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	if err := newTestLeaseLock(client, "replica-1").Create(newTestElectionRecord("replica-1", 0)); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	lock := newTestLeaseLock(client, "replica-2")
+	ler := newTestElectionRecord("replica-2", 1)
+	if err := lock.Update(ler); err == nil {
+		t.Fatalf("expect error if Update is called before Get or Create")
+	}
+	if _, err := lock.Get(); err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if err := lock.Update(ler); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	got, err := newTestLeaseLock(client, "replica-1").Get()
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if !reflect.DeepEqual(*got, ler) {
+		t.Errorf("expect record %+v, got %+v", ler, *got)
+	}
+
+	// the lock keeps the updated Lease, so that it can be updated again without Get
+	ler.RenewTime = metav1.NewTime(ler.RenewTime.Add(time.Second))
+	if err := lock.Update(ler); err != nil {
+		t.Errorf("expect second update to succeed, got %v", err)
+	}
+}
+
+func TestLeaseLockRecordEvent(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	lock := newTestLeaseLock(client, "replica-1")
+	recorder := lock.LockConfig.EventRecorder.(*record.FakeRecorder)
+
+	lock.RecordEvent("became leader")
+	if len(recorder.Events) != 0 {
+		t.Errorf("expect no event before the Lease is known")
+	}
+	if err := lock.Create(newTestElectionRecord("replica-1", 0)); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	lock.RecordEvent("became leader")
+	if event := <-recorder.Events; !strings.Contains(event, "replica-1 became leader") {
+		t.Errorf("unexpected event %q", event)
+	}
+	if lock.Describe() != "kube-system/lbcf-controller" || lock.Identity() != "replica-1" {
+		t.Errorf("unexpected lock %s, identity %s", lock.Describe(), lock.Identity())
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// SecretCacheTTL is how long a Secret read by SecretGetter is cached
+const SecretCacheTTL = 30 * time.Second
+
+// SecretGetter gets the Secrets referenced by LoadBalancerDriver.spec.clientConfig
+type SecretGetter interface {
+	GetSecret(namespace, name string) (*v1.Secret, error)
+}
+
+// NewSecretGetter creates a SecretGetter that reads Secrets from kube-apiserver on demand.
+//
+// Secrets are not listed and watched, only the ones referenced by drivers are read and cached for SecretCacheTTL,
+// so that lbcf-controller neither needs permission to list Secrets nor keeps all Secrets of the cluster in memory.
+func NewSecretGetter(client corev1.SecretsGetter) SecretGetter {
+	return &secretCache{
+		client:  client,
+		ttl:     SecretCacheTTL,
+		now:     time.Now,
+		entries: make(map[string]*secretCacheEntry),
+	}
+}
+
+type secretCache struct {
+	sync.Mutex
+	client  corev1.SecretsGetter
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]*secretCacheEntry
+}
+
+type secretCacheEntry struct {
+	secret  *v1.Secret
+	err     error
+	expires time.Time
+}
+
+// GetSecret returns the cached Secret if it is not expired, otherwise the Secret is read from kube-apiserver.
+// NotFound errors are cached as well, so that a driver referencing a missing Secret does not flood kube-apiserver
+func (c *secretCache) GetSecret(namespace, name string) (*v1.Secret, error) {
+	key := NamespacedNameKeyFunc(namespace, name)
+	c.Lock()
+	entry, ok := c.entries[key]
+	c.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.secret, entry.err
+	}
+
+	secret, err := c.client.Secrets(namespace).Get(name, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.entries[key] = &secretCacheEntry{
+		secret:  secret,
+		err:     err,
+		expires: c.now().Add(c.ttl),
+	}
+	c.evictExpired()
+	return secret, err
+}
+
+// evictExpired removes expired entries so that Secrets no longer referenced by any driver are released
+func (c *secretCache) evictExpired() {
+	now := c.now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func countSecretGets(client *fake.Clientset) int {
+	count := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "get" && action.GetResource().Resource == "secrets" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSecretCache(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "driver-token",
+			Namespace:       "kube-system",
+			ResourceVersion: "1",
+		},
+		Data: map[string][]byte{"token": []byte("abc")},
+	})
+	now := time.Now()
+	getter := NewSecretGetter(client.CoreV1()).(*secretCache)
+	getter.now = func() time.Time { return now }
+
+	secret, err := getter.GetSecret("kube-system", "driver-token")
+	if err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if string(secret.Data["token"]) != "abc" {
+		t.Fatalf("unexpected secret: %+v", secret)
+	}
+	if _, err := getter.GetSecret("kube-system", "driver-token"); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if n := countSecretGets(client); n != 1 {
+		t.Errorf("expect 1 get before ttl expires, got %d", n)
+	}
+
+	now = now.Add(SecretCacheTTL)
+	if _, err := getter.GetSecret("kube-system", "driver-token"); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if n := countSecretGets(client); n != 2 {
+		t.Errorf("expect secret to be read again after ttl expires, got %d gets", n)
+	}
+}
+
+func TestSecretCacheNotFound(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	now := time.Now()
+	getter := NewSecretGetter(client.CoreV1()).(*secretCache)
+	getter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := getter.GetSecret("kube-system", "not-exist"); !errors.IsNotFound(err) {
+			t.Fatalf("expect NotFound, got %v", err)
+		}
+	}
+	if n := countSecretGets(client); n != 1 {
+		t.Errorf("expect NotFound to be cached, got %d gets", n)
+	}
+
+	now = now.Add(SecretCacheTTL)
+	getter.GetSecret("kube-system", "other")
+	if _, ok := getter.entries[NamespacedNameKeyFunc("kube-system", "not-exist")]; ok {
+		t.Errorf("expect expired entry to be evicted")
+	}
+}
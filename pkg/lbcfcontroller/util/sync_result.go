@@ -35,6 +35,23 @@ func ErrorResult(err error) *SyncResult {
 	}
 }
 
+// WebhookErrorResult returns a SyncResult for the error returned by WebhookInvoker,
+// calls rejected by an open circuit are retried once when the circuit allows a trial call
+func WebhookErrorResult(err error) *SyncResult {
+	if e, ok := err.(*CircuitOpenError); ok {
+		return FailResult(e.RetryAfter, e.Error())
+	}
+	return ErrorResult(err)
+}
+
+// ThrottledResult returns a new SyncResult that call IsThrottled() on it will return true,
+// it is used if the operation is not started because too many operations are in flight on the driver
+func ThrottledResult() *SyncResult {
+	return &SyncResult{
+		throttled: true,
+	}
+}
+
 // FailResult returns a new SyncResult that call IsFailed() on it will return true
 func FailResult(delay time.Duration, msg string) *SyncResult {
 	return &SyncResult{
@@ -66,14 +83,15 @@ func PeriodicResult(period time.Duration) *SyncResult {
 
 // SyncResult stores result for sync method of controllers
 type SyncResult struct {
-	faild    *failedOp
-	async    *asyncOp
-	periodic *periodicOp
+	faild     *failedOp
+	async     *asyncOp
+	periodic  *periodicOp
+	throttled bool
 }
 
 // IsFinished indicates the operation is successfully finished
 func (s *SyncResult) IsFinished() bool {
-	return !s.IsFailed() && !s.IsRunning() && !s.IsPeriodic()
+	return !s.IsFailed() && !s.IsRunning() && !s.IsPeriodic() && !s.IsThrottled()
 }
 
 // IsFailed indicates no error occured during operation, but the operation failed
@@ -91,6 +109,11 @@ func (s *SyncResult) IsPeriodic() bool {
 	return s.periodic != nil
 }
 
+// IsThrottled indicates the operation is not started and should be retried with a growing delay
+func (s *SyncResult) IsThrottled() bool {
+	return s.throttled
+}
+
 // GetFailReason returns the error stored in SyncResult
 func (s *SyncResult) GetFailReason() string {
 	if s.faild == nil {
@@ -0,0 +1,77 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// NewSyncTracker creates a new SyncTracker
+func NewSyncTracker() *SyncTracker {
+	return &SyncTracker{
+		running: make(map[uint64]runningSync),
+	}
+}
+
+// SyncTracker records syncs in progress, so that workers stuck in a sync can be found
+type SyncTracker struct {
+	sync.Mutex
+	nextID  uint64
+	running map[uint64]runningSync
+}
+
+type runningSync struct {
+	queue string
+	key   string
+	start time.Time
+}
+
+// Start records that key from queue is being synced, the returned function must be called when the sync finishes
+func (t *SyncTracker) Start(queue string, key string) func() {
+	t.Lock()
+	defer t.Unlock()
+	id := t.nextID
+	t.nextID++
+	t.running[id] = runningSync{
+		queue: queue,
+		key:   key,
+		start: time.Now(),
+	}
+	return func() {
+		t.Lock()
+		defer t.Unlock()
+		delete(t.running, id)
+	}
+}
+
+// Stuck returns syncs that have been running longer than threshold, in the form of "queue/key (duration)"
+func (t *SyncTracker) Stuck(threshold time.Duration) []string {
+	t.Lock()
+	defer t.Unlock()
+	var stuck []string
+	for _, s := range t.running {
+		if elapsed := time.Since(s.start); elapsed > threshold {
+			stuck = append(stuck, fmt.Sprintf("%s/%s (%s)", s.queue, s.key, elapsed.Round(time.Second)))
+		}
+	}
+	sort.Strings(stuck)
+	return stuck
+}
@@ -21,10 +21,13 @@ import (
 	"crypto/md5"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/endpointslice"
 
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,11 +44,52 @@ const (
 
 	// DefaultEnsurePeriod is the default minimum interval for ensureLoadBalancer and ensureBackendRecord
 	DefaultEnsurePeriod = 1 * time.Minute
+
+	// DefaultHealthCheckPeriod is the default interval between driver health checks
+	DefaultHealthCheckPeriod = 30 * time.Second
+
+	// DefaultHealthCheckTimeout is the default timeout of driver health checks
+	DefaultHealthCheckTimeout = 5 * time.Second
+
+	// DriverUnhealthyRetryDelay is the minimum delay before retrying an operation on an unhealthy driver
+	DriverUnhealthyRetryDelay = 30 * time.Second
 )
 
-// PodAvailable indicates the given pod is ready to bind to load balancers
+// PodAvailable indicates the given pod is ready to bind to load balancers.
+// Pods with readiness gate lbcf.tke.cloud.tencent.com/registered can't be Ready before they are bound,
+// so ContainersReady is checked instead of Ready for them.
 func PodAvailable(obj *v1.Pod) bool {
-	return obj.Status.PodIP != "" && obj.DeletionTimestamp == nil && pod.IsPodReady(obj)
+	if obj.Status.PodIP == "" || obj.DeletionTimestamp != nil {
+		return false
+	}
+	if HasRegisteredReadinessGate(obj) {
+		_, cond := pod.GetPodCondition(&obj.Status, v1.ContainersReady)
+		return cond != nil && cond.Status == v1.ConditionTrue
+	}
+	return pod.IsPodReady(obj)
+}
+
+// NodeAvailable indicates the given node is ready to bind to load balancers
+func NodeAvailable(node *v1.Node) bool {
+	if node.DeletionTimestamp != nil {
+		return false
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == v1.NodeReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// HasRegisteredReadinessGate indicates whether the pod has readiness gate lbcf.tke.cloud.tencent.com/registered
+func HasRegisteredReadinessGate(obj *v1.Pod) bool {
+	for _, gate := range obj.Spec.ReadinessGates {
+		if gate.ConditionType == lbcfapi.PodConditionRegistered {
+			return true
+		}
+	}
+	return false
 }
 
 // LBCreated indicates the given LoadBalancer is successfully created by webhook createLoadBalancer
@@ -57,6 +101,18 @@ func LBCreated(lb *lbcfapi.LoadBalancer) bool {
 	return condition.Status == lbcfapi.ConditionTrue
 }
 
+// GetDeletionPolicy returns the deletion policy of lb,
+// imported LoadBalancers are retained unless the policy is explicitly set to Delete
+func GetDeletionPolicy(lb *lbcfapi.LoadBalancer) lbcfapi.DeletionPolicy {
+	if lb.Spec.DeletionPolicy != "" {
+		return lb.Spec.DeletionPolicy
+	}
+	if lb.Spec.Import {
+		return lbcfapi.DeletionPolicyRetain
+	}
+	return lbcfapi.DeletionPolicyDelete
+}
+
 // LBEnsured indicates the given LoadBalancer is successfully ensured by webhook ensureLoadBalancer
 func LBEnsured(lb *lbcfapi.LoadBalancer) bool {
 	condition := GetLBCondition(&lb.Status, lbcfapi.LBAttributesSynced)
@@ -135,6 +191,9 @@ const (
 	// TypeStatic indicates the BackendGroup consists of static addresses
 	TypeStatic BackendType = "Static"
 
+	// TypeNode indicates the BackendGroup consists of nodes
+	TypeNode BackendType = "Node"
+
 	// TypeUnknown indicates the BackendGroup consists of unknown backends
 	TypeUnknown BackendType = "Unknown"
 )
@@ -145,10 +204,36 @@ func GetBackendType(bg *lbcfapi.BackendGroup) BackendType {
 		return TypePod
 	} else if bg.Spec.Service != nil {
 		return TypeService
+	} else if bg.Spec.Nodes != nil {
+		return TypeNode
 	}
 	return TypeStatic
 }
 
+// GetBackendPorts returns the ports selected by bg, nil is returned for BackendGroups of type static
+func GetBackendPorts(bg *lbcfapi.BackendGroup) []lbcfapi.PortSelector {
+	if bg.Spec.Pods != nil {
+		if len(bg.Spec.Pods.Ports) > 0 {
+			return bg.Spec.Pods.Ports
+		}
+		return []lbcfapi.PortSelector{bg.Spec.Pods.Port}
+	} else if bg.Spec.Service != nil {
+		if len(bg.Spec.Service.Ports) > 0 {
+			return bg.Spec.Service.Ports
+		}
+		return []lbcfapi.PortSelector{bg.Spec.Service.Port}
+	} else if bg.Spec.Nodes != nil {
+		return []lbcfapi.PortSelector{bg.Spec.Nodes.Port}
+	}
+	return nil
+}
+
+// IsPortListUsed indicates whether bg selects ports by spec.pods.ports or spec.service.ports
+func IsPortListUsed(bg *lbcfapi.BackendGroup) bool {
+	return (bg.Spec.Pods != nil && len(bg.Spec.Pods.Ports) > 0) ||
+		(bg.Spec.Service != nil && len(bg.Spec.Service.Ports) > 0)
+}
+
 // GetDriverNamespace returns the namespace where the driver is created.
 // It returns "kube-system" if driverName starts with "lbcf-", otherwise the defaultNamespace is returned
 func GetDriverNamespace(driverName string, defaultNamespace string) string {
@@ -158,6 +243,68 @@ func GetDriverNamespace(driverName string, defaultNamespace string) string {
 	return defaultNamespace
 }
 
+// GetLBNamespace returns the namespace of the LoadBalancer used by group
+func GetLBNamespace(group *lbcfapi.BackendGroup) string {
+	if group.Spec.LBNamespace != "" {
+		return group.Spec.LBNamespace
+	}
+	return group.Namespace
+}
+
+// GetBackendLBNamespace returns the namespace of the LoadBalancer that backend is registered to
+func GetBackendLBNamespace(backend *lbcfapi.BackendRecord) string {
+	if backend.Spec.LBNamespace != "" {
+		return backend.Spec.LBNamespace
+	}
+	return backend.Namespace
+}
+
+// IsNamespaceAllowed returns true if BackendGroups in namespace are allowed to use lb
+func IsNamespaceAllowed(lb *lbcfapi.LoadBalancer, namespace string) bool {
+	if lb.Namespace == namespace {
+		return true
+	}
+	for _, allowed := range lb.Spec.AllowedNamespaces {
+		if allowed == lbcfapi.AllNamespaces || allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDriverHealthy returns false if the Healthy condition of driver is False,
+// drivers without health check are always regarded as healthy
+func IsDriverHealthy(driver *lbcfapi.LoadBalancerDriver) bool {
+	condition := GetDriverCondition(&driver.Status, lbcfapi.DriverHealthy)
+	if condition == nil {
+		return true
+	}
+	return condition.Status != lbcfapi.ConditionFalse
+}
+
+// GetDriverCondition is an helper function to get specific LoadBalancerDriver condition
+func GetDriverCondition(status *lbcfapi.LoadBalancerDriverStatus,
+	conditionType lbcfapi.LoadBalancerDriverConditionType) *lbcfapi.LoadBalancerDriverCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// AddDriverCondition is an helper function to add specific LoadBalancerDriver condition.
+// If a condition with same type exists, the existing one will be overwritten, otherwise, a new condition will be inserted.
+func AddDriverCondition(status *lbcfapi.LoadBalancerDriverStatus, expectCondition lbcfapi.LoadBalancerDriverCondition) {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == expectCondition.Type {
+			status.Conditions[i] = expectCondition
+			return
+		}
+	}
+	status.Conditions = append(status.Conditions, expectCondition)
+}
+
 // IsDriverDraining indicates whether driver is draining
 func IsDriverDraining(driver *lbcfapi.LoadBalancerDriver) bool {
 	if v, ok := driver.Labels[lbcfapi.DriverDrainingLabel]; !ok || strings.ToUpper(v) != "TRUE" {
@@ -251,6 +398,27 @@ func MakeStaticBackendName(lbName, groupName, staticAddr string) string {
 	return fmt.Sprintf("%x", h)
 }
 
+// MakeNodeBackendName generates a name for BackendRecord of node type.
+// IPs of node are part of the name, so that the BackendRecord is replaced if the IPs of node are changed
+func MakeNodeBackendName(lbName, groupName string, node *v1.Node, port lbcfapi.PortSelector) string {
+	raw := fmt.Sprintf("%s_%s_%s_%d_%s_%s", lbName, groupName, node.Name, port.PortNumber, port.Protocol,
+		strings.Join(nodeIPs(node), ","))
+	h := md5.Sum([]byte(raw))
+	return fmt.Sprintf("%x", h)
+}
+
+// nodeIPs returns the sorted InternalIPs and ExternalIPs of node
+func nodeIPs(node *v1.Node) []string {
+	var ips []string
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == v1.NodeInternalIP || addr.Type == v1.NodeExternalIP {
+			ips = append(ips, fmt.Sprintf("%s=%s", addr.Type, addr.Address))
+		}
+	}
+	sort.Strings(ips)
+	return ips
+}
+
 // MakeBackendLabels generates labels for BackendRecord
 func MakeBackendLabels(driverName, lbName, groupName, svcName, podName string) map[string]string {
 	ret := make(map[string]string)
@@ -266,13 +434,18 @@ func MakeBackendLabels(driverName, lbName, groupName, svcName, podName string) m
 	return ret
 }
 
-// ConstructPodBackendRecord constructs a new BackendRecord
+// ConstructPodBackendRecord constructs a new BackendRecord for port of pod,
+// nil is returned if port can not be resolved in pod
 func ConstructPodBackendRecord(lb *lbcfapi.LoadBalancer,
-	group *lbcfapi.BackendGroup, pod *v1.Pod) *lbcfapi.BackendRecord {
+	group *lbcfapi.BackendGroup, pod *v1.Pod, wantedPort lbcfapi.PortSelector) *lbcfapi.BackendRecord {
+	port := ResolvePodPort(pod, wantedPort)
+	if port == nil {
+		return nil
+	}
 	valueTrue := true
 	return &lbcfapi.BackendRecord{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      MakePodBackendName(lb.Name, group.Name, pod.UID, group.Spec.Pods.Port),
+			Name:      MakePodBackendName(lb.Name, group.Name, pod.UID, *port),
 			Namespace: group.Namespace,
 			Labels:    MakeBackendLabels(lb.Spec.LBDriver, lb.Name, group.Name, "", pod.Name),
 			Finalizers: []string{
@@ -291,30 +464,75 @@ func ConstructPodBackendRecord(lb *lbcfapi.LoadBalancer,
 		},
 		Spec: lbcfapi.BackendRecordSpec{
 			LBName:       lb.Name,
+			LBNamespace:  lb.Namespace,
 			LBDriver:     lb.Spec.LBDriver,
 			LBInfo:       lb.Status.LBInfo,
 			LBAttributes: lb.Spec.Attributes,
 			PodBackendInfo: &lbcfapi.PodBackendRecord{
-				Name: pod.Name,
-				Port: group.Spec.Pods.Port,
+				Name:          pod.Name,
+				Port:          *port,
+				DrainPeriod:   GetDrainPeriod(group),
+				ReadinessGate: group.Spec.Pods.ReadinessGate,
 			},
 			Parameters:   group.Spec.Parameters,
 			EnsurePolicy: group.Spec.EnsurePolicy,
+			Weight:       GetPodWeight(group, pod),
 		},
 	}
 }
 
-// ConstructServiceBackendRecord constructs a new BackendRecord of type service
-func ConstructServiceBackendRecord(lb *lbcfapi.LoadBalancer,
-	group *lbcfapi.BackendGroup, svc *v1.Service, node *v1.Node) *lbcfapi.BackendRecord {
-	var selectedSvcPort *v1.ServicePort
-	wantedPort := group.Spec.Service.Port
-	for i, svcPort := range svc.Spec.Ports {
-		if svcPort.Port == wantedPort.PortNumber && string(svcPort.Protocol) == wantedPort.Protocol {
-			selectedSvcPort = &svc.Spec.Ports[i]
-			break
+// ResolvePodPort resolves port against container ports of pod if port is selected by name,
+// nil is returned if no container port matches
+func ResolvePodPort(pod *v1.Pod, port lbcfapi.PortSelector) *lbcfapi.PortSelector {
+	if port.PortName == "" {
+		return &port
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port.PortName && string(containerPort.Protocol) == port.Protocol {
+				port.PortNumber = containerPort.ContainerPort
+				return &port
+			}
+		}
+	}
+	return nil
+}
+
+// GetPodWeight returns weight of pod in group, nil is returned if weight is not configured.
+// The weight is read from pod annotation, if the annotation is not set or invalid, the default weight is returned.
+func GetPodWeight(group *lbcfapi.BackendGroup, pod *v1.Pod) *int32 {
+	cfg := group.Spec.Pods.Weight
+	if cfg == nil {
+		return nil
+	}
+	annotation := cfg.Annotation
+	if annotation == "" {
+		annotation = lbcfapi.AnnotationWeight
+	}
+	if v, ok := pod.Annotations[annotation]; ok {
+		w, err := strconv.ParseInt(v, 10, 32)
+		if err == nil && w >= 0 {
+			weight := int32(w)
+			return &weight
 		}
+		klog.Warningf("invalid weight %q in annotation %s of pod %s/%s", v, annotation, pod.Namespace, pod.Name)
 	}
+	if cfg.DefaultWeight == nil {
+		return nil
+	}
+	weight := *cfg.DefaultWeight
+	return &weight
+}
+
+// IsWeightEnabled indicates whether weight of pods selected by group is read from pod annotations
+func IsWeightEnabled(group *lbcfapi.BackendGroup) bool {
+	return group.Spec.Pods != nil && group.Spec.Pods.Weight != nil
+}
+
+// ConstructServiceBackendRecord constructs a new BackendRecord of type service
+func ConstructServiceBackendRecord(lb *lbcfapi.LoadBalancer,
+	group *lbcfapi.BackendGroup, svc *v1.Service, node *v1.Node, wantedPort lbcfapi.PortSelector) *lbcfapi.BackendRecord {
+	selectedSvcPort := FindServicePort(svc, wantedPort)
 	if selectedSvcPort == nil || selectedSvcPort.NodePort == 0 {
 		return nil
 	}
@@ -342,12 +560,17 @@ func ConstructServiceBackendRecord(lb *lbcfapi.LoadBalancer,
 		},
 		Spec: lbcfapi.BackendRecordSpec{
 			LBName:       lb.Name,
+			LBNamespace:  lb.Namespace,
 			LBDriver:     lb.Spec.LBDriver,
 			LBInfo:       lb.Status.LBInfo,
 			LBAttributes: lb.Spec.Attributes,
 			ServiceBackendInfo: &lbcfapi.ServiceBackendRecord{
-				Name:     svc.Name,
-				Port:     group.Spec.Service.Port,
+				Name: svc.Name,
+				Port: lbcfapi.PortSelector{
+					PortNumber: selectedSvcPort.Port,
+					PortName:   wantedPort.PortName,
+					Protocol:   string(selectedSvcPort.Protocol),
+				},
 				NodePort: selectedSvcPort.NodePort,
 				NodeName: node.Name,
 			},
@@ -357,6 +580,72 @@ func ConstructServiceBackendRecord(lb *lbcfapi.LoadBalancer,
 	}
 }
 
+// FindServicePort returns the port in svc that matches wantedPort, nil is returned if not found.
+// If wantedPort is selected by name, it is matched against ServicePort.Name
+func FindServicePort(svc *v1.Service, wantedPort lbcfapi.PortSelector) *v1.ServicePort {
+	for i, svcPort := range svc.Spec.Ports {
+		if string(svcPort.Protocol) != wantedPort.Protocol {
+			continue
+		}
+		if wantedPort.PortName != "" && svcPort.Name == wantedPort.PortName {
+			return &svc.Spec.Ports[i]
+		} else if wantedPort.PortName == "" && svcPort.Port == wantedPort.PortNumber {
+			return &svc.Spec.Ports[i]
+		}
+	}
+	return nil
+}
+
+// ConstructEndpointPodBackendRecord constructs a new BackendRecord of type pod for a pod endpoint of svc,
+// pod is the targetRef of the endpoint
+func ConstructEndpointPodBackendRecord(lb *lbcfapi.LoadBalancer, group *lbcfapi.BackendGroup,
+	svc *v1.Service, pod *v1.ObjectReference, podPort lbcfapi.PortSelector) *lbcfapi.BackendRecord {
+	valueTrue := true
+	return &lbcfapi.BackendRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MakePodBackendName(lb.Name, group.Name, pod.UID, podPort),
+			Namespace: group.Namespace,
+			Labels:    MakeBackendLabels(lb.Spec.LBDriver, lb.Name, group.Name, svc.Name, pod.Name),
+			Finalizers: []string{
+				lbcfapi.FinalizerDeregisterBackend,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         lbcfapi.ApiVersion,
+					BlockOwnerDeletion: &valueTrue,
+					Controller:         &valueTrue,
+					Kind:               "BackendGroup",
+					Name:               group.Name,
+					UID:                group.UID,
+				},
+			},
+		},
+		Spec: lbcfapi.BackendRecordSpec{
+			LBName:       lb.Name,
+			LBNamespace:  lb.Namespace,
+			LBDriver:     lb.Spec.LBDriver,
+			LBInfo:       lb.Status.LBInfo,
+			LBAttributes: lb.Spec.Attributes,
+			PodBackendInfo: &lbcfapi.PodBackendRecord{
+				Name: pod.Name,
+				Port: podPort,
+			},
+			Parameters:   group.Spec.Parameters,
+			EnsurePolicy: group.Spec.EnsurePolicy,
+		},
+	}
+}
+
+// IsEndpointSliceMatchBackendGroup returns true if group registers endpoints of the service that owns slice
+func IsEndpointSliceMatchBackendGroup(group *lbcfapi.BackendGroup, slice *endpointslice.EndpointSlice) bool {
+	if group.Spec.Service == nil || group.Namespace != slice.Namespace ||
+		group.Spec.Service.Name != slice.Labels[endpointslice.LabelServiceName] {
+		return false
+	}
+	mode := group.Spec.Service.Mode
+	return mode == lbcfapi.ServiceModeEndpointPod || mode == lbcfapi.ServiceModeEndpointNode
+}
+
 // ConstructStaticBackend constructs BackendRecords of type service
 func ConstructStaticBackend(lb *lbcfapi.LoadBalancer,
 	group *lbcfapi.BackendGroup, staticAddr string) *lbcfapi.BackendRecord {
@@ -382,6 +671,7 @@ func ConstructStaticBackend(lb *lbcfapi.LoadBalancer,
 		},
 		Spec: lbcfapi.BackendRecordSpec{
 			LBName:       lb.Name,
+			LBNamespace:  lb.Namespace,
 			LBDriver:     lb.Spec.LBDriver,
 			LBInfo:       lb.Status.LBInfo,
 			LBAttributes: lb.Spec.Attributes,
@@ -392,6 +682,47 @@ func ConstructStaticBackend(lb *lbcfapi.LoadBalancer,
 	}
 }
 
+// ConstructNodeBackendRecord constructs a new BackendRecord of type node
+func ConstructNodeBackendRecord(lb *lbcfapi.LoadBalancer,
+	group *lbcfapi.BackendGroup, node *v1.Node) *lbcfapi.BackendRecord {
+	valueTrue := true
+	labels := MakeBackendLabels(lb.Spec.LBDriver, lb.Name, group.Name, "", "")
+	labels[lbcfapi.LabelNodeName] = node.Name
+	return &lbcfapi.BackendRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MakeNodeBackendName(lb.Name, group.Name, node, group.Spec.Nodes.Port),
+			Namespace: group.Namespace,
+			Labels:    labels,
+			Finalizers: []string{
+				lbcfapi.FinalizerDeregisterBackend,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         lbcfapi.ApiVersion,
+					BlockOwnerDeletion: &valueTrue,
+					Controller:         &valueTrue,
+					Kind:               "BackendGroup",
+					Name:               group.Name,
+					UID:                group.UID,
+				},
+			},
+		},
+		Spec: lbcfapi.BackendRecordSpec{
+			LBName:       lb.Name,
+			LBNamespace:  lb.Namespace,
+			LBDriver:     lb.Spec.LBDriver,
+			LBInfo:       lb.Status.LBInfo,
+			LBAttributes: lb.Spec.Attributes,
+			NodeBackendInfo: &lbcfapi.NodeBackendRecord{
+				Name: node.Name,
+				Port: group.Spec.Nodes.Port,
+			},
+			Parameters:   group.Spec.Parameters,
+			EnsurePolicy: group.Spec.EnsurePolicy,
+		},
+	}
+}
+
 func needUpdateRecord(curObj *lbcfapi.BackendRecord, expectObj *lbcfapi.BackendRecord) bool {
 	if !reflect.DeepEqual(curObj.Spec.LBAttributes, expectObj.Spec.LBAttributes) {
 		return true
@@ -402,9 +733,41 @@ func needUpdateRecord(curObj *lbcfapi.BackendRecord, expectObj *lbcfapi.BackendR
 	if !reflect.DeepEqual(curObj.Spec.EnsurePolicy, expectObj.Spec.EnsurePolicy) {
 		return true
 	}
+	if !reflect.DeepEqual(curObj.Spec.Weight, expectObj.Spec.Weight) {
+		return true
+	}
+	if curObj.Spec.PodBackendInfo != nil && expectObj.Spec.PodBackendInfo != nil &&
+		!reflect.DeepEqual(curObj.Spec.PodBackendInfo, expectObj.Spec.PodBackendInfo) {
+		return true
+	}
 	return false
 }
 
+// GetDrainPeriod returns the drainPeriod of group, nil is returned if drain mode is not enabled
+func GetDrainPeriod(group *lbcfapi.BackendGroup) *lbcfapi.Duration {
+	if group.Spec.Pods == nil || group.Spec.Pods.Drain == nil {
+		return nil
+	}
+	period := group.Spec.Pods.Drain.DrainPeriod
+	return &period
+}
+
+// IsDrainModeEnabled indicates whether pods selected by group should be drained before deletion
+func IsDrainModeEnabled(group *lbcfapi.BackendGroup) bool {
+	return group.Spec.Pods != nil && group.Spec.Pods.Drain != nil
+}
+
+// NeedDrain indicates whether the backend should be drained after it is deregistered
+func NeedDrain(backend *lbcfapi.BackendRecord) bool {
+	return backend.Spec.PodBackendInfo != nil && backend.Spec.PodBackendInfo.DrainPeriod != nil
+}
+
+// BackendDrained indicates whether the backend has been deregistered and drainPeriod has passed
+func BackendDrained(backend *lbcfapi.BackendRecord) bool {
+	cond := GetBackendRecordCondition(&backend.Status, lbcfapi.BackendDrained)
+	return cond != nil && cond.Status == lbcfapi.ConditionTrue
+}
+
 // IterateBackends runs handler on every BackendRecord in all and returns error if any error occurs
 func IterateBackends(all []*lbcfapi.BackendRecord, handler func(*lbcfapi.BackendRecord) error) error {
 	var errList []error
@@ -455,16 +818,55 @@ func IsPodMatchBackendGroup(group *lbcfapi.BackendGroup, pod *v1.Pod) bool {
 		if except.Has(pod.Name) {
 			return false
 		}
-		selector := k8slabel.SelectorFromSet(k8slabel.Set(group.Spec.Pods.ByLabel.Selector))
+		selector, err := PodSelector(group.Spec.Pods.ByLabel)
+		if err != nil {
+			klog.Errorf("invalid pod selector in BackendGroup %s/%s: %v", group.Namespace, group.Name, err)
+			return false
+		}
 		return selector.Matches(k8slabel.Set(pod.Labels))
 	}
 	included := sets.NewString(group.Spec.Pods.ByName...)
 	return included.Has(pod.Name)
 }
 
+// IsNodeMatchBackendGroup returns true if node is selected by group of type node
+func IsNodeMatchBackendGroup(group *lbcfapi.BackendGroup, node *v1.Node) bool {
+	if group.Spec.Nodes == nil {
+		return false
+	}
+	selector, err := NodeSelector(group.Spec.Nodes)
+	if err != nil {
+		klog.Errorf("invalid node selector in BackendGroup %s/%s: %v", group.Namespace, group.Name, err)
+		return false
+	}
+	return selector.Matches(k8slabel.Set(node.Labels))
+}
+
+// PodSelector converts byLabel into a label selector
+func PodSelector(byLabel *lbcfapi.SelectPodByLabel) (k8slabel.Selector, error) {
+	return buildSelector(byLabel.Selector, byLabel.MatchExpressions)
+}
+
+// NodeSelector converts the selector of a node backend into a label selector
+func NodeSelector(nodes *lbcfapi.NodeBackend) (k8slabel.Selector, error) {
+	return buildSelector(nodes.Selector, nodes.MatchExpressions)
+}
+
+// ServiceNodeSelector converts the node selector of a service backend into a label selector
+func ServiceNodeSelector(svc *lbcfapi.ServiceBackend) (k8slabel.Selector, error) {
+	return buildSelector(svc.NodeSelector, svc.NodeMatchExpressions)
+}
+
+func buildSelector(matchLabels map[string]string, exprs []metav1.LabelSelectorRequirement) (k8slabel.Selector, error) {
+	return metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels:      matchLabels,
+		MatchExpressions: exprs,
+	})
+}
+
 // IsLBMatchBackendGroup returns true if group is connected to lb
 func IsLBMatchBackendGroup(group *lbcfapi.BackendGroup, lb *lbcfapi.LoadBalancer) bool {
-	if group.Namespace == lb.Namespace && group.Spec.LBName == lb.Name {
+	if GetLBNamespace(group) == lb.Namespace && group.Spec.LBName == lb.Name {
 		return true
 	}
 	return false
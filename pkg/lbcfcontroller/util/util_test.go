@@ -0,0 +1,601 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabel "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestNode(addrs ...v1.NodeAddress) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status:     v1.NodeStatus{Addresses: addrs},
+	}
+}
+
+func TestMakeNodeBackendName(t *testing.T) {
+	port := lbcfapi.PortSelector{PortNumber: 80, Protocol: "TCP"}
+	internal := v1.NodeAddress{Type: v1.NodeInternalIP, Address: "10.0.0.1"}
+	external := v1.NodeAddress{Type: v1.NodeExternalIP, Address: "1.1.1.1"}
+	hostname := v1.NodeAddress{Type: v1.NodeHostName, Address: "node-1"}
+
+	name := MakeNodeBackendName("lb", "group", newTestNode(internal, external, hostname), port)
+
+	if n := MakeNodeBackendName("lb", "group", newTestNode(external, internal), port); n != name {
+		t.Errorf("expect name not to depend on order of addresses or hostname")
+	}
+	changed := newTestNode(v1.NodeAddress{Type: v1.NodeInternalIP, Address: "10.0.0.2"}, external, hostname)
+	if n := MakeNodeBackendName("lb", "group", changed, port); n == name {
+		t.Errorf("expect name to change with InternalIP")
+	}
+	if n := MakeNodeBackendName("lb", "group", newTestNode(internal, hostname), port); n == name {
+		t.Errorf("expect name to change with ExternalIP")
+	}
+	if n := MakeNodeBackendName("lb", "group", newTestNode(internal, external, hostname),
+		lbcfapi.PortSelector{PortNumber: 81, Protocol: "TCP"}); n == name {
+		t.Errorf("expect name to change with port")
+	}
+}
+
+func TestDriverImplementsImportLoadBalancer(t *testing.T) {
+	driver := &lbcfapi.LoadBalancerDriver{}
+	if !DriverImplements(driver, webhooks.EnsureBackend) {
+		t.Errorf("expect v1 driver to implement %s", webhooks.EnsureBackend)
+	}
+	if DriverImplements(driver, webhooks.ImportLoadBalancer) {
+		t.Errorf("expect v1 driver not to implement %s unless configured", webhooks.ImportLoadBalancer)
+	}
+	driver.Spec.Webhooks = []lbcfapi.WebhookConfig{{Name: webhooks.ImportLoadBalancer}}
+	if !DriverImplements(driver, webhooks.ImportLoadBalancer) {
+		t.Errorf("expect driver to implement configured webhook %s", webhooks.ImportLoadBalancer)
+	}
+}
+
+func TestDriverImplementsByProtocolVersion(t *testing.T) {
+	v1Driver := &lbcfapi.LoadBalancerDriver{}
+	v2Driver := &lbcfapi.LoadBalancerDriver{
+		Spec: lbcfapi.LoadBalancerDriverSpec{
+			ProtocolVersion: lbcfapi.ProtocolVersionV2,
+			Webhooks:        []lbcfapi.WebhookConfig{{Name: webhooks.EnsureBackend}},
+		},
+	}
+	for known := range webhooks.KnownWebhooks {
+		if !DriverImplements(v1Driver, known) {
+			t.Errorf("expect v1 driver to implement %s", known)
+		}
+	}
+	for optional := range webhooks.OptionalWebhooks {
+		if DriverImplements(v1Driver, optional) {
+			t.Errorf("expect v1 driver not to implement %s unless configured", optional)
+		}
+	}
+	if !DriverImplements(v2Driver, webhooks.EnsureBackend) {
+		t.Errorf("expect v2 driver to implement configured webhook %s", webhooks.EnsureBackend)
+	}
+	if DriverImplements(v2Driver, webhooks.ValidateBackend) {
+		t.Errorf("expect v2 driver not to implement %s unless configured", webhooks.ValidateBackend)
+	}
+}
+
+func TestGetPodWeight(t *testing.T) {
+	defaultWeight := int32(10)
+	cases := []struct {
+		name        string
+		weight      *lbcfapi.WeightConfig
+		annotations map[string]string
+		expect      *int32
+	}{
+		{
+			name:        "not-enabled",
+			annotations: map[string]string{lbcfapi.AnnotationWeight: "20"},
+		},
+		{
+			name:        "default-annotation",
+			weight:      &lbcfapi.WeightConfig{DefaultWeight: &defaultWeight},
+			annotations: map[string]string{lbcfapi.AnnotationWeight: "20"},
+			expect:      int32Ptr(20),
+		},
+		{
+			name:        "custom-annotation",
+			weight:      &lbcfapi.WeightConfig{Annotation: "example.com/weight", DefaultWeight: &defaultWeight},
+			annotations: map[string]string{lbcfapi.AnnotationWeight: "20", "example.com/weight": "30"},
+			expect:      int32Ptr(30),
+		},
+		{
+			name:        "zero",
+			weight:      &lbcfapi.WeightConfig{DefaultWeight: &defaultWeight},
+			annotations: map[string]string{lbcfapi.AnnotationWeight: "0"},
+			expect:      int32Ptr(0),
+		},
+		{
+			name:   "missing-annotation",
+			weight: &lbcfapi.WeightConfig{DefaultWeight: &defaultWeight},
+			expect: int32Ptr(10),
+		},
+		{
+			name:        "not-a-number",
+			weight:      &lbcfapi.WeightConfig{DefaultWeight: &defaultWeight},
+			annotations: map[string]string{lbcfapi.AnnotationWeight: "heavy"},
+			expect:      int32Ptr(10),
+		},
+		{
+			name:        "negative",
+			weight:      &lbcfapi.WeightConfig{DefaultWeight: &defaultWeight},
+			annotations: map[string]string{lbcfapi.AnnotationWeight: "-1"},
+			expect:      int32Ptr(10),
+		},
+		{
+			name:        "overflow",
+			weight:      &lbcfapi.WeightConfig{DefaultWeight: &defaultWeight},
+			annotations: map[string]string{lbcfapi.AnnotationWeight: "4294967296"},
+			expect:      int32Ptr(10),
+		},
+		{
+			name:        "invalid-without-default",
+			weight:      &lbcfapi.WeightConfig{},
+			annotations: map[string]string{lbcfapi.AnnotationWeight: "heavy"},
+		},
+		{
+			name:   "missing-without-default",
+			weight: &lbcfapi.WeightConfig{},
+		},
+	}
+	for _, c := range cases {
+		group := &lbcfapi.BackendGroup{
+			Spec: lbcfapi.BackendGroupSpec{
+				Pods: &lbcfapi.PodBackend{Weight: c.weight},
+			},
+		}
+		pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default", Annotations: c.annotations}}
+		got := GetPodWeight(group, pod)
+		if (got == nil) != (c.expect == nil) || (got != nil && *got != *c.expect) {
+			t.Errorf("case %s: expect %v, got %v", c.name, formatInt32Ptr(c.expect), formatInt32Ptr(got))
+		}
+	}
+
+	// the default weight of group must not be shared with BackendRecords
+	group := &lbcfapi.BackendGroup{
+		Spec: lbcfapi.BackendGroupSpec{
+			Pods: &lbcfapi.PodBackend{Weight: &lbcfapi.WeightConfig{DefaultWeight: &defaultWeight}},
+		},
+	}
+	if got := GetPodWeight(group, &v1.Pod{}); got == &defaultWeight {
+		t.Errorf("expect a copy of the default weight")
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func formatInt32Ptr(i *int32) string {
+	if i == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%d", *i)
+}
+
+func TestResolvePodPort(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: "app",
+					Ports: []v1.ContainerPort{
+						{Name: "http", ContainerPort: 8080, Protocol: v1.ProtocolTCP},
+						{Name: "dns", ContainerPort: 53, Protocol: v1.ProtocolUDP},
+					},
+				},
+				{
+					Name:  "sidecar",
+					Ports: []v1.ContainerPort{{Name: "metrics", ContainerPort: 9090, Protocol: v1.ProtocolTCP}},
+				},
+			},
+		},
+	}
+	cases := []struct {
+		name   string
+		port   lbcfapi.PortSelector
+		expect *lbcfapi.PortSelector
+	}{
+		{
+			name:   "by-number",
+			port:   lbcfapi.PortSelector{PortNumber: 80, Protocol: "TCP"},
+			expect: &lbcfapi.PortSelector{PortNumber: 80, Protocol: "TCP"},
+		},
+		{
+			name:   "by-name",
+			port:   lbcfapi.PortSelector{PortName: "http", Protocol: "TCP"},
+			expect: &lbcfapi.PortSelector{PortName: "http", PortNumber: 8080, Protocol: "TCP"},
+		},
+		{
+			name:   "by-name-in-other-container",
+			port:   lbcfapi.PortSelector{PortName: "metrics", Protocol: "TCP"},
+			expect: &lbcfapi.PortSelector{PortName: "metrics", PortNumber: 9090, Protocol: "TCP"},
+		},
+		{
+			name:   "by-name-udp",
+			port:   lbcfapi.PortSelector{PortName: "dns", Protocol: "UDP"},
+			expect: &lbcfapi.PortSelector{PortName: "dns", PortNumber: 53, Protocol: "UDP"},
+		},
+		{
+			name: "protocol-mismatch",
+			port: lbcfapi.PortSelector{PortName: "http", Protocol: "UDP"},
+		},
+		{
+			name: "name-not-found",
+			port: lbcfapi.PortSelector{PortName: "grpc", Protocol: "TCP"},
+		},
+	}
+	for _, c := range cases {
+		got := ResolvePodPort(pod, c.port)
+		if (got == nil) != (c.expect == nil) || (got != nil && *got != *c.expect) {
+			t.Errorf("case %s: expect %+v, got %+v", c.name, c.expect, got)
+		}
+	}
+}
+
+func TestFindServicePort(t *testing.T) {
+	svc := &v1.Service{
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{Name: "http", Port: 80, Protocol: v1.ProtocolTCP, NodePort: 30080},
+				{Name: "dns", Port: 53, Protocol: v1.ProtocolUDP, NodePort: 30053},
+				{Name: "dns-tcp", Port: 53, Protocol: v1.ProtocolTCP, NodePort: 30054},
+			},
+		},
+	}
+	cases := []struct {
+		name           string
+		port           lbcfapi.PortSelector
+		expectNodePort int32
+	}{
+		{
+			name:           "by-number",
+			port:           lbcfapi.PortSelector{PortNumber: 80, Protocol: "TCP"},
+			expectNodePort: 30080,
+		},
+		{
+			name:           "by-number-udp",
+			port:           lbcfapi.PortSelector{PortNumber: 53, Protocol: "UDP"},
+			expectNodePort: 30053,
+		},
+		{
+			name:           "by-number-same-port-other-protocol",
+			port:           lbcfapi.PortSelector{PortNumber: 53, Protocol: "TCP"},
+			expectNodePort: 30054,
+		},
+		{
+			name:           "by-name",
+			port:           lbcfapi.PortSelector{PortName: "dns", Protocol: "UDP"},
+			expectNodePort: 30053,
+		},
+		{
+			name:           "name-takes-precedence-over-number",
+			port:           lbcfapi.PortSelector{PortName: "http", PortNumber: 53, Protocol: "TCP"},
+			expectNodePort: 30080,
+		},
+		{
+			name: "by-name-protocol-mismatch",
+			port: lbcfapi.PortSelector{PortName: "http", Protocol: "UDP"},
+		},
+		{
+			name: "by-number-protocol-mismatch",
+			port: lbcfapi.PortSelector{PortNumber: 80, Protocol: "UDP"},
+		},
+		{
+			name: "name-not-found",
+			port: lbcfapi.PortSelector{PortName: "grpc", Protocol: "TCP"},
+		},
+	}
+	for _, c := range cases {
+		got := FindServicePort(svc, c.port)
+		if c.expectNodePort == 0 {
+			if got != nil {
+				t.Errorf("case %s: expect no port, got %+v", c.name, got)
+			}
+			continue
+		}
+		if got == nil || got.NodePort != c.expectNodePort {
+			t.Errorf("case %s: expect nodePort %d, got %+v", c.name, c.expectNodePort, got)
+		}
+	}
+}
+
+func TestGetBackendPorts(t *testing.T) {
+	http := lbcfapi.PortSelector{PortNumber: 80, Protocol: "TCP"}
+	dns := lbcfapi.PortSelector{PortNumber: 53, Protocol: "UDP"}
+	cases := []struct {
+		name           string
+		spec           lbcfapi.BackendGroupSpec
+		expectPorts    []lbcfapi.PortSelector
+		expectPortList bool
+	}{
+		{
+			name:        "pods-port",
+			spec:        lbcfapi.BackendGroupSpec{Pods: &lbcfapi.PodBackend{Port: http}},
+			expectPorts: []lbcfapi.PortSelector{http},
+		},
+		{
+			name:           "pods-ports",
+			spec:           lbcfapi.BackendGroupSpec{Pods: &lbcfapi.PodBackend{Ports: []lbcfapi.PortSelector{http, dns}}},
+			expectPorts:    []lbcfapi.PortSelector{http, dns},
+			expectPortList: true,
+		},
+		{
+			name:           "pods-single-item-ports",
+			spec:           lbcfapi.BackendGroupSpec{Pods: &lbcfapi.PodBackend{Ports: []lbcfapi.PortSelector{dns}}},
+			expectPorts:    []lbcfapi.PortSelector{dns},
+			expectPortList: true,
+		},
+		{
+			name:        "service-port",
+			spec:        lbcfapi.BackendGroupSpec{Service: &lbcfapi.ServiceBackend{Name: "svc", Port: http}},
+			expectPorts: []lbcfapi.PortSelector{http},
+		},
+		{
+			name: "service-ports",
+			spec: lbcfapi.BackendGroupSpec{
+				Service: &lbcfapi.ServiceBackend{Name: "svc", Ports: []lbcfapi.PortSelector{http, dns}},
+			},
+			expectPorts:    []lbcfapi.PortSelector{http, dns},
+			expectPortList: true,
+		},
+		{
+			name:        "nodes",
+			spec:        lbcfapi.BackendGroupSpec{Nodes: &lbcfapi.NodeBackend{Port: http}},
+			expectPorts: []lbcfapi.PortSelector{http},
+		},
+		{
+			name: "static",
+			spec: lbcfapi.BackendGroupSpec{Static: []string{"1.1.1.1:80"}},
+		},
+	}
+	for _, c := range cases {
+		bg := &lbcfapi.BackendGroup{Spec: c.spec}
+		if got := GetBackendPorts(bg); !reflect.DeepEqual(got, c.expectPorts) {
+			t.Errorf("case %s: expect ports %+v, got %+v", c.name, c.expectPorts, got)
+		}
+		if got := IsPortListUsed(bg); got != c.expectPortList {
+			t.Errorf("case %s: expect IsPortListUsed %v, got %v", c.name, c.expectPortList, got)
+		}
+	}
+}
+
+func TestMakePodBackendNameForMultiplePorts(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-1", UID: "pod-uid-1"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: "app",
+					Ports: []v1.ContainerPort{
+						{Name: "http", ContainerPort: 80, Protocol: v1.ProtocolTCP},
+					},
+				},
+			},
+		},
+	}
+	lb := &lbcfapi.LoadBalancer{ObjectMeta: metav1.ObjectMeta{Name: "lb"}}
+	tcp80 := lbcfapi.PortSelector{PortNumber: 80, Protocol: "TCP"}
+	group := &lbcfapi.BackendGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "group"},
+		Spec:       lbcfapi.BackendGroupSpec{Pods: &lbcfapi.PodBackend{Ports: []lbcfapi.PortSelector{tcp80}}},
+	}
+	name := ConstructPodBackendRecord(lb, group, pod, tcp80).Name
+
+	cases := []struct {
+		name       string
+		lbName     string
+		groupName  string
+		podUID     string
+		port       lbcfapi.PortSelector
+		expectSame bool
+	}{
+		{
+			name:       "same-port",
+			port:       tcp80,
+			expectSame: true,
+		},
+		{
+			name:       "named-port-resolves-to-same-number",
+			port:       lbcfapi.PortSelector{PortName: "http", Protocol: "TCP"},
+			expectSame: true,
+		},
+		{
+			name: "other-port",
+			port: lbcfapi.PortSelector{PortNumber: 8080, Protocol: "TCP"},
+		},
+		{
+			name: "same-port-other-protocol",
+			port: lbcfapi.PortSelector{PortNumber: 80, Protocol: "UDP"},
+		},
+		{
+			name:   "other-lb",
+			lbName: "lb-2",
+			port:   tcp80,
+		},
+		{
+			name:      "other-group",
+			groupName: "group-2",
+			port:      tcp80,
+		},
+		{
+			name:   "other-pod",
+			podUID: "pod-uid-2",
+			port:   tcp80,
+		},
+	}
+	for _, c := range cases {
+		l, g, p := lb.DeepCopy(), group.DeepCopy(), pod.DeepCopy()
+		if c.lbName != "" {
+			l.Name = c.lbName
+		}
+		if c.groupName != "" {
+			g.Name = c.groupName
+		}
+		if c.podUID != "" {
+			p.UID = types.UID(c.podUID)
+		}
+		record := ConstructPodBackendRecord(l, g, p, c.port)
+		if record == nil {
+			t.Fatalf("case %s: expect port %+v to be resolved", c.name, c.port)
+		}
+		if same := record.Name == name; same != c.expectSame {
+			t.Errorf("case %s: expect same name %v, got %v", c.name, c.expectSame, same)
+		}
+	}
+}
+
+func TestBuildSelectorWithMatchExpressions(t *testing.T) {
+	type expectMatch struct {
+		labels map[string]string
+		match  bool
+	}
+	cases := []struct {
+		name        string
+		matchLabels map[string]string
+		exprs       []metav1.LabelSelectorRequirement
+		expectErr   bool
+		expect      []expectMatch
+	}{
+		{
+			name: "in",
+			exprs: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"a", "b"}},
+			},
+			expect: []expectMatch{
+				{labels: map[string]string{"app": "a"}, match: true},
+				{labels: map[string]string{"app": "b"}, match: true},
+				{labels: map[string]string{"app": "c"}},
+				{labels: map[string]string{}},
+			},
+		},
+		{
+			name: "not-in",
+			exprs: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"a"}},
+			},
+			expect: []expectMatch{
+				{labels: map[string]string{"app": "a"}},
+				{labels: map[string]string{"app": "b"}, match: true},
+				{labels: map[string]string{}, match: true},
+			},
+		},
+		{
+			name: "exists",
+			exprs: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: metav1.LabelSelectorOpExists},
+			},
+			expect: []expectMatch{
+				{labels: map[string]string{"app": ""}, match: true},
+				{labels: map[string]string{"other": "a"}},
+			},
+		},
+		{
+			name: "does-not-exist",
+			exprs: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: metav1.LabelSelectorOpDoesNotExist},
+			},
+			expect: []expectMatch{
+				{labels: map[string]string{"app": "a"}},
+				{labels: map[string]string{"other": "a"}, match: true},
+			},
+		},
+		{
+			name:        "anded-with-match-labels",
+			matchLabels: map[string]string{"tier": "web"},
+			exprs: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"a"}},
+				{Key: "canary", Operator: metav1.LabelSelectorOpDoesNotExist},
+			},
+			expect: []expectMatch{
+				{labels: map[string]string{"tier": "web", "app": "a"}, match: true},
+				{labels: map[string]string{"tier": "web", "app": "a", "canary": "true"}},
+				{labels: map[string]string{"tier": "db", "app": "a"}},
+				{labels: map[string]string{"app": "a"}},
+			},
+		},
+		{
+			name:        "match-labels-only",
+			matchLabels: map[string]string{"tier": "web"},
+			expect: []expectMatch{
+				{labels: map[string]string{"tier": "web", "app": "a"}, match: true},
+				{labels: map[string]string{"tier": "db"}},
+			},
+		},
+		{
+			name: "invalid-operator",
+			exprs: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: "Equals", Values: []string{"a"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "in-without-values",
+			exprs: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: metav1.LabelSelectorOpIn},
+			},
+			expectErr: true,
+		},
+	}
+	for _, c := range cases {
+		builders := map[string]func() (k8slabel.Selector, error){
+			"pods": func() (k8slabel.Selector, error) {
+				return PodSelector(&lbcfapi.SelectPodByLabel{Selector: c.matchLabels, MatchExpressions: c.exprs})
+			},
+			"nodes": func() (k8slabel.Selector, error) {
+				return NodeSelector(&lbcfapi.NodeBackend{Selector: c.matchLabels, MatchExpressions: c.exprs})
+			},
+			"service": func() (k8slabel.Selector, error) {
+				return ServiceNodeSelector(&lbcfapi.ServiceBackend{
+					NodeSelector:         c.matchLabels,
+					NodeMatchExpressions: c.exprs,
+				})
+			},
+		}
+		for kind, build := range builders {
+			selector, err := build()
+			if c.expectErr {
+				if err == nil {
+					t.Errorf("case %s/%s: expect error", c.name, kind)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("case %s/%s: unexpected error %v", c.name, kind, err)
+				continue
+			}
+			for _, e := range c.expect {
+				if got := selector.Matches(k8slabel.Set(e.labels)); got != e.match {
+					t.Errorf("case %s/%s: labels %v, expect match %v, got %v", c.name, kind, e.labels, e.match, got)
+				}
+			}
+		}
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	lbcfapi "tkestack.io/lb-controlling-framework/pkg/apis/lbcf.tke.cloud.tencent.com/v1beta1"
+	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/metrics"
 	"tkestack.io/lb-controlling-framework/pkg/lbcfcontroller/webhooks"
 
 	"github.com/parnurzeal/gorequest"
@@ -40,6 +41,9 @@ type WebhookInvoker interface {
 	CallCreateLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
 		req *webhooks.CreateLoadBalancerRequest) (*webhooks.CreateLoadBalancerResponse, error)
 
+	CallImportLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
+		req *webhooks.ImportLoadBalancerRequest) (*webhooks.ImportLoadBalancerResponse, error)
+
 	CallEnsureLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
 		req *webhooks.EnsureLoadBalancerRequest) (*webhooks.EnsureLoadBalancerResponse, error)
 
@@ -57,21 +61,53 @@ type WebhookInvoker interface {
 
 	CallDeregisterBackend(driver *lbcfapi.LoadBalancerDriver,
 		req *webhooks.BackendOperationRequest) (*webhooks.BackendOperationResponse, error)
+
+	CallBatchEnsureBackend(driver *lbcfapi.LoadBalancerDriver,
+		req *webhooks.BatchBackendOperationRequest) (*webhooks.BatchBackendOperationResponse, error)
+
+	CallBatchDeregisterBackend(driver *lbcfapi.LoadBalancerDriver,
+		req *webhooks.BatchBackendOperationRequest) (*webhooks.BatchBackendOperationResponse, error)
+
+	ProbeDriver(driver *lbcfapi.LoadBalancerDriver) error
+
+	CircuitOpen(driver *lbcfapi.LoadBalancerDriver) bool
+
+	OnCircuitChange(handler func(driverKey string))
 }
 
-// NewWebhookInvoker creates a new instance of WebhookInvoker
-func NewWebhookInvoker() WebhookInvoker {
-	return &WebhookInvokerImpl{}
+// NewWebhookInvoker creates a new instance of WebhookInvoker,
+// secrets is used to read Secrets referenced by LoadBalancerDriver.spec.clientConfig
+func NewWebhookInvoker(secrets SecretGetter) WebhookInvoker {
+	credentials := newCredentialStore(secrets)
+	breaker := newCircuitBreaker()
+	return &WebhookInvokerImpl{
+		credentials: credentials,
+		breaker:     breaker,
+		grpc:        newGRPCInvoker(credentials, breaker),
+	}
 }
 
-// WebhookInvokerImpl is an implementation of WebhookInvoker
-type WebhookInvokerImpl struct{}
+// WebhookInvokerImpl is an implementation of WebhookInvoker.
+//
+// Drivers of type Webhook are called via HTTP, drivers of type GRPC are called via gRPC.
+// Calls to a driver fail fast with CircuitOpenError if too many recent calls to it failed.
+type WebhookInvokerImpl struct {
+	credentials *credentialStore
+	breaker     *circuitBreaker
+	grpc        *grpcInvoker
+}
 
 // CallValidateLoadBalancer calls webhook validateLoadBalancer on driver
 func (w *WebhookInvokerImpl) CallValidateLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
-	req *webhooks.ValidateLoadBalancerRequest) (*webhooks.ValidateLoadBalancerResponse, error) {
-	rsp := &webhooks.ValidateLoadBalancerResponse{}
-	if err := callWebhook(driver, webhooks.ValidateLoadBalancer, req, rsp); err != nil {
+	req *webhooks.ValidateLoadBalancerRequest) (rsp *webhooks.ValidateLoadBalancerResponse, err error) {
+	defer func(start time.Time) {
+		observeWebhook(driver, webhooks.ValidateLoadBalancer, start, rsp, err)
+	}(time.Now())
+	if isGRPCDriver(driver) {
+		return w.grpc.callValidateLoadBalancer(driver, req)
+	}
+	rsp = &webhooks.ValidateLoadBalancerResponse{}
+	if err := w.callWebhook(driver, webhooks.ValidateLoadBalancer, req, rsp); err != nil {
 		return nil, err
 	}
 	return rsp, nil
@@ -79,9 +115,31 @@ func (w *WebhookInvokerImpl) CallValidateLoadBalancer(driver *lbcfapi.LoadBalanc
 
 // CallCreateLoadBalancer calls webhook createLoadBalancer on driver
 func (w *WebhookInvokerImpl) CallCreateLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
-	req *webhooks.CreateLoadBalancerRequest) (*webhooks.CreateLoadBalancerResponse, error) {
-	rsp := &webhooks.CreateLoadBalancerResponse{}
-	if err := callWebhook(driver, webhooks.CreateLoadBalancer, req, rsp); err != nil {
+	req *webhooks.CreateLoadBalancerRequest) (rsp *webhooks.CreateLoadBalancerResponse, err error) {
+	defer func(start time.Time) {
+		observeWebhook(driver, webhooks.CreateLoadBalancer, start, rsp, err)
+	}(time.Now())
+	if isGRPCDriver(driver) {
+		return w.grpc.callCreateLoadBalancer(driver, req)
+	}
+	rsp = &webhooks.CreateLoadBalancerResponse{}
+	if err := w.callWebhook(driver, webhooks.CreateLoadBalancer, req, rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// CallImportLoadBalancer calls webhook importLoadBalancer on driver
+func (w *WebhookInvokerImpl) CallImportLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.ImportLoadBalancerRequest) (rsp *webhooks.ImportLoadBalancerResponse, err error) {
+	defer func(start time.Time) {
+		observeWebhook(driver, webhooks.ImportLoadBalancer, start, rsp, err)
+	}(time.Now())
+	if isGRPCDriver(driver) {
+		return w.grpc.callImportLoadBalancer(driver, req)
+	}
+	rsp = &webhooks.ImportLoadBalancerResponse{}
+	if err := w.callWebhook(driver, webhooks.ImportLoadBalancer, req, rsp); err != nil {
 		return nil, err
 	}
 	return rsp, nil
@@ -89,9 +147,15 @@ func (w *WebhookInvokerImpl) CallCreateLoadBalancer(driver *lbcfapi.LoadBalancer
 
 // CallEnsureLoadBalancer calls webhook ensureLoadBalancer on driver
 func (w *WebhookInvokerImpl) CallEnsureLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
-	req *webhooks.EnsureLoadBalancerRequest) (*webhooks.EnsureLoadBalancerResponse, error) {
-	rsp := &webhooks.EnsureLoadBalancerResponse{}
-	if err := callWebhook(driver, webhooks.EnsureLoadBalancer, req, rsp); err != nil {
+	req *webhooks.EnsureLoadBalancerRequest) (rsp *webhooks.EnsureLoadBalancerResponse, err error) {
+	defer func(start time.Time) {
+		observeWebhook(driver, webhooks.EnsureLoadBalancer, start, rsp, err)
+	}(time.Now())
+	if isGRPCDriver(driver) {
+		return w.grpc.callEnsureLoadBalancer(driver, req)
+	}
+	rsp = &webhooks.EnsureLoadBalancerResponse{}
+	if err := w.callWebhook(driver, webhooks.EnsureLoadBalancer, req, rsp); err != nil {
 		return nil, err
 	}
 	return rsp, nil
@@ -99,9 +163,15 @@ func (w *WebhookInvokerImpl) CallEnsureLoadBalancer(driver *lbcfapi.LoadBalancer
 
 // CallDeleteLoadBalancer calls webhook deleteLoadBalancer on driver
 func (w *WebhookInvokerImpl) CallDeleteLoadBalancer(driver *lbcfapi.LoadBalancerDriver,
-	req *webhooks.DeleteLoadBalancerRequest) (*webhooks.DeleteLoadBalancerResponse, error) {
-	rsp := &webhooks.DeleteLoadBalancerResponse{}
-	if err := callWebhook(driver, webhooks.DeleteLoadBalancer, req, rsp); err != nil {
+	req *webhooks.DeleteLoadBalancerRequest) (rsp *webhooks.DeleteLoadBalancerResponse, err error) {
+	defer func(start time.Time) {
+		observeWebhook(driver, webhooks.DeleteLoadBalancer, start, rsp, err)
+	}(time.Now())
+	if isGRPCDriver(driver) {
+		return w.grpc.callDeleteLoadBalancer(driver, req)
+	}
+	rsp = &webhooks.DeleteLoadBalancerResponse{}
+	if err := w.callWebhook(driver, webhooks.DeleteLoadBalancer, req, rsp); err != nil {
 		return nil, err
 	}
 	return rsp, nil
@@ -109,9 +179,15 @@ func (w *WebhookInvokerImpl) CallDeleteLoadBalancer(driver *lbcfapi.LoadBalancer
 
 // CallValidateBackend calls webhook validateBackend on driver
 func (w *WebhookInvokerImpl) CallValidateBackend(driver *lbcfapi.LoadBalancerDriver,
-	req *webhooks.ValidateBackendRequest) (*webhooks.ValidateBackendResponse, error) {
-	rsp := &webhooks.ValidateBackendResponse{}
-	if err := callWebhook(driver, webhooks.ValidateBackend, req, rsp); err != nil {
+	req *webhooks.ValidateBackendRequest) (rsp *webhooks.ValidateBackendResponse, err error) {
+	defer func(start time.Time) {
+		observeWebhook(driver, webhooks.ValidateBackend, start, rsp, err)
+	}(time.Now())
+	if isGRPCDriver(driver) {
+		return w.grpc.callValidateBackend(driver, req)
+	}
+	rsp = &webhooks.ValidateBackendResponse{}
+	if err := w.callWebhook(driver, webhooks.ValidateBackend, req, rsp); err != nil {
 		return nil, err
 	}
 	return rsp, nil
@@ -119,9 +195,15 @@ func (w *WebhookInvokerImpl) CallValidateBackend(driver *lbcfapi.LoadBalancerDri
 
 // CallGenerateBackendAddr calls webhook generateBackendAddr on driver
 func (w *WebhookInvokerImpl) CallGenerateBackendAddr(driver *lbcfapi.LoadBalancerDriver,
-	req *webhooks.GenerateBackendAddrRequest) (*webhooks.GenerateBackendAddrResponse, error) {
-	rsp := &webhooks.GenerateBackendAddrResponse{}
-	if err := callWebhook(driver, webhooks.GenerateBackendAddr, req, rsp); err != nil {
+	req *webhooks.GenerateBackendAddrRequest) (rsp *webhooks.GenerateBackendAddrResponse, err error) {
+	defer func(start time.Time) {
+		observeWebhook(driver, webhooks.GenerateBackendAddr, start, rsp, err)
+	}(time.Now())
+	if isGRPCDriver(driver) {
+		return w.grpc.callGenerateBackendAddr(driver, req)
+	}
+	rsp = &webhooks.GenerateBackendAddrResponse{}
+	if err := w.callWebhook(driver, webhooks.GenerateBackendAddr, req, rsp); err != nil {
 		return nil, err
 	}
 	return rsp, nil
@@ -129,9 +211,15 @@ func (w *WebhookInvokerImpl) CallGenerateBackendAddr(driver *lbcfapi.LoadBalance
 
 // CallEnsureBackend calls webhook ensureBackend on driver
 func (w *WebhookInvokerImpl) CallEnsureBackend(driver *lbcfapi.LoadBalancerDriver,
-	req *webhooks.BackendOperationRequest) (*webhooks.BackendOperationResponse, error) {
-	rsp := &webhooks.BackendOperationResponse{}
-	if err := callWebhook(driver, webhooks.EnsureBackend, req, rsp); err != nil {
+	req *webhooks.BackendOperationRequest) (rsp *webhooks.BackendOperationResponse, err error) {
+	defer func(start time.Time) {
+		observeWebhook(driver, webhooks.EnsureBackend, start, rsp, err)
+	}(time.Now())
+	if isGRPCDriver(driver) {
+		return w.grpc.callEnsureBackend(driver, req)
+	}
+	rsp = &webhooks.BackendOperationResponse{}
+	if err := w.callWebhook(driver, webhooks.EnsureBackend, req, rsp); err != nil {
 		return nil, err
 	}
 	return rsp, nil
@@ -139,32 +227,135 @@ func (w *WebhookInvokerImpl) CallEnsureBackend(driver *lbcfapi.LoadBalancerDrive
 
 // CallDeregisterBackend calls webhook deregisterBackend on driver
 func (w *WebhookInvokerImpl) CallDeregisterBackend(driver *lbcfapi.LoadBalancerDriver,
-	req *webhooks.BackendOperationRequest) (*webhooks.BackendOperationResponse, error) {
-	rsp := &webhooks.BackendOperationResponse{}
-	if err := callWebhook(driver, webhooks.DeregBackend, req, rsp); err != nil {
+	req *webhooks.BackendOperationRequest) (rsp *webhooks.BackendOperationResponse, err error) {
+	defer func(start time.Time) {
+		observeWebhook(driver, webhooks.DeregBackend, start, rsp, err)
+	}(time.Now())
+	if isGRPCDriver(driver) {
+		return w.grpc.callDeregisterBackend(driver, req)
+	}
+	rsp = &webhooks.BackendOperationResponse{}
+	if err := w.callWebhook(driver, webhooks.DeregBackend, req, rsp); err != nil {
 		return nil, err
 	}
 	return rsp, nil
 }
 
-func callWebhook(driver *lbcfapi.LoadBalancerDriver, webHookName string, payload interface{}, rsp interface{}) error {
+// CallBatchEnsureBackend calls webhook batchEnsureBackend on driver
+func (w *WebhookInvokerImpl) CallBatchEnsureBackend(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.BatchBackendOperationRequest) (rsp *webhooks.BatchBackendOperationResponse, err error) {
+	defer func(start time.Time) {
+		observeWebhook(driver, webhooks.BatchEnsureBackend, start, rsp, err)
+	}(time.Now())
+	if isGRPCDriver(driver) {
+		return w.grpc.callBatchEnsureBackend(driver, req)
+	}
+	rsp = &webhooks.BatchBackendOperationResponse{}
+	if err := w.callWebhook(driver, webhooks.BatchEnsureBackend, req, rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// CallBatchDeregisterBackend calls webhook batchDeregisterBackend on driver
+func (w *WebhookInvokerImpl) CallBatchDeregisterBackend(driver *lbcfapi.LoadBalancerDriver,
+	req *webhooks.BatchBackendOperationRequest) (rsp *webhooks.BatchBackendOperationResponse, err error) {
+	defer func(start time.Time) {
+		observeWebhook(driver, webhooks.BatchDeregBackend, start, rsp, err)
+	}(time.Now())
+	if isGRPCDriver(driver) {
+		return w.grpc.callBatchDeregisterBackend(driver, req)
+	}
+	rsp = &webhooks.BatchBackendOperationResponse{}
+	if err := w.callWebhook(driver, webhooks.BatchDeregBackend, req, rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// ProbeDriver checks the health of driver as configured in driver.spec.healthCheck
+func (w *WebhookInvokerImpl) ProbeDriver(driver *lbcfapi.LoadBalancerDriver) error {
+	if driver.Spec.HealthCheck == nil {
+		return nil
+	}
+	timeout := GetDuration(driver.Spec.HealthCheck.Timeout, DefaultHealthCheckTimeout)
+	if isGRPCDriver(driver) {
+		return w.grpc.probe(driver, timeout)
+	}
+	cred, err := w.credentials.get(driver)
+	if err != nil {
+		return err
+	}
+	u, err := url.Parse(driver.Spec.Url)
+	if err != nil {
+		return fmt.Errorf("invalid url: %v", err)
+	}
+	if err := checkTokenTransport(cred, u.Scheme == "https"); err != nil {
+		return err
+	}
+	u.Path = path.Join("/", driver.Spec.HealthCheck.Path)
+	request := gorequest.New().Timeout(timeout).Get(u.String())
+	if cred.tlsConfig != nil {
+		request.TLSClientConfig(cred.tlsConfig)
+	}
+	if cred.token != "" {
+		request.Set("Authorization", "Bearer "+cred.token)
+	}
+	response, body, errs := request.EndBytes()
+	if len(errs) > 0 {
+		return fmt.Errorf("probe err: %v", errs)
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("http status code: %d, body: %s", response.StatusCode, body)
+	}
+	return nil
+}
+
+// CircuitOpen returns true if calls to driver fail fast because the circuit of driver is open
+func (w *WebhookInvokerImpl) CircuitOpen(driver *lbcfapi.LoadBalancerDriver) bool {
+	return w.breaker.isOpen(NamespacedNameKeyFunc(driver.Namespace, driver.Name))
+}
+
+// OnCircuitChange sets a handler that is called with namespace/name of the driver
+// when the circuit of the driver is opened or closed
+func (w *WebhookInvokerImpl) OnCircuitChange(handler func(driverKey string)) {
+	w.breaker.setOnChange(handler)
+}
+
+func (w *WebhookInvokerImpl) callWebhook(driver *lbcfapi.LoadBalancerDriver, webHookName string, payload interface{}, rsp interface{}) (err error) {
+	cred, err := w.credentials.get(driver)
+	if err != nil {
+		klog.Errorf("callwebhook failed: %v. driver: %s, webhookName: %s", err, driver.Name, webHookName)
+		return err
+	}
 	u, err := url.Parse(driver.Spec.Url)
 	if err != nil {
 		e := fmt.Errorf("invalid url: %v", err)
 		klog.Errorf("callwebhook failed: %v. driver: %s, webhookName: %s", e, driver.Name, webHookName)
 		return e
 	}
+	if err := checkTokenTransport(cred, u.Scheme == "https"); err != nil {
+		klog.Errorf("callwebhook failed: %v. driver: %s, webhookName: %s", err, driver.Name, webHookName)
+		return err
+	}
+	driverKey := NamespacedNameKeyFunc(driver.Namespace, driver.Name)
+	if err := w.breaker.allow(driverKey); err != nil {
+		return err
+	}
+	defer func() {
+		w.breaker.done(driverKey, err)
+	}()
 	u.Path = path.Join(webHookName)
-	var timeout time.Duration
-	for _, h := range driver.Spec.Webhooks {
-		if h.Name == webHookName {
-			timeout = h.Timeout.Duration
-			break
-		}
+	request := gorequest.New().Timeout(getWebhookTimeout(driver, webHookName)).Post(u.String()).Send(payload)
+	if cred.tlsConfig != nil {
+		request.TLSClientConfig(cred.tlsConfig)
 	}
-	request := gorequest.New().Timeout(timeout).Post(u.String()).Send(payload)
 	debugInfo, _ := request.AsCurlCommand()
 	klog.V(3).Infof("callwebhook, %s", debugInfo)
+	// set after AsCurlCommand so that token is never logged
+	if cred.token != "" {
+		request.Set("Authorization", "Bearer "+cred.token)
+	}
 
 	response, body, errs := request.EndBytes()
 	if len(errs) > 0 {
@@ -184,3 +375,50 @@ func callWebhook(driver *lbcfapi.LoadBalancerDriver, webHookName string, payload
 	}
 	return nil
 }
+
+// GetProtocolVersion returns the protocol version of driver, defaults to v1
+func GetProtocolVersion(driver *lbcfapi.LoadBalancerDriver) lbcfapi.ProtocolVersion {
+	if driver.Spec.ProtocolVersion == "" {
+		return lbcfapi.ProtocolVersionV1
+	}
+	return driver.Spec.ProtocolVersion
+}
+
+// DriverImplements returns true if driver implements webhook webHookName.
+//
+// Drivers of protocol version v1 implement all webhooks in KnownWebhooks,
+// other webhooks are implemented only if they are configured in driver.spec.webhooks
+func DriverImplements(driver *lbcfapi.LoadBalancerDriver, webHookName string) bool {
+	if GetProtocolVersion(driver) == lbcfapi.ProtocolVersionV1 && webhooks.KnownWebhooks.Has(webHookName) {
+		return true
+	}
+	for _, h := range driver.Spec.Webhooks {
+		if h.Name == webHookName {
+			return true
+		}
+	}
+	return false
+}
+
+func getWebhookTimeout(driver *lbcfapi.LoadBalancerDriver, webHookName string) time.Duration {
+	for _, h := range driver.Spec.Webhooks {
+		if h.Name == webHookName {
+			return h.Timeout.Duration
+		}
+	}
+	return 0
+}
+
+type webhookResponse interface {
+	GetStatus() string
+}
+
+// observeWebhook records metrics for a finished webhook call, rsp is ignored if err is not nil
+func observeWebhook(driver *lbcfapi.LoadBalancerDriver, webHookName string, start time.Time,
+	rsp webhookResponse, err error) {
+	status := metrics.WebhookStatusError
+	if err == nil {
+		status = rsp.GetStatus()
+	}
+	metrics.WebhookCalled(NamespacedNameKeyFunc(driver.Namespace, driver.Name), webHookName, status, time.Since(start))
+}
@@ -0,0 +1,495 @@
+//
+// Tencent is pleased to support the open source community by making TKEStack available.
+//
+// Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+// this file except in compliance with the License. You may obtain a copy of the
+// License at
+//
+// https://opensource.org/licenses/Apache-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.21.12
+// source: driver.proto
+
+package driverpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	LoadBalancerDriver_ValidateLoadBalancer_FullMethodName   = "/lbcf.driver.v1beta1.LoadBalancerDriver/ValidateLoadBalancer"
+	LoadBalancerDriver_CreateLoadBalancer_FullMethodName     = "/lbcf.driver.v1beta1.LoadBalancerDriver/CreateLoadBalancer"
+	LoadBalancerDriver_ImportLoadBalancer_FullMethodName     = "/lbcf.driver.v1beta1.LoadBalancerDriver/ImportLoadBalancer"
+	LoadBalancerDriver_EnsureLoadBalancer_FullMethodName     = "/lbcf.driver.v1beta1.LoadBalancerDriver/EnsureLoadBalancer"
+	LoadBalancerDriver_DeleteLoadBalancer_FullMethodName     = "/lbcf.driver.v1beta1.LoadBalancerDriver/DeleteLoadBalancer"
+	LoadBalancerDriver_ValidateBackend_FullMethodName        = "/lbcf.driver.v1beta1.LoadBalancerDriver/ValidateBackend"
+	LoadBalancerDriver_GenerateBackendAddr_FullMethodName    = "/lbcf.driver.v1beta1.LoadBalancerDriver/GenerateBackendAddr"
+	LoadBalancerDriver_EnsureBackend_FullMethodName          = "/lbcf.driver.v1beta1.LoadBalancerDriver/EnsureBackend"
+	LoadBalancerDriver_DeregisterBackend_FullMethodName      = "/lbcf.driver.v1beta1.LoadBalancerDriver/DeregisterBackend"
+	LoadBalancerDriver_BatchEnsureBackend_FullMethodName     = "/lbcf.driver.v1beta1.LoadBalancerDriver/BatchEnsureBackend"
+	LoadBalancerDriver_BatchDeregisterBackend_FullMethodName = "/lbcf.driver.v1beta1.LoadBalancerDriver/BatchDeregisterBackend"
+)
+
+// LoadBalancerDriverClient is the client API for LoadBalancerDriver service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LoadBalancerDriverClient interface {
+	ValidateLoadBalancer(ctx context.Context, in *ValidateLoadBalancerRequest, opts ...grpc.CallOption) (*ValidateLoadBalancerResponse, error)
+	CreateLoadBalancer(ctx context.Context, in *CreateLoadBalancerRequest, opts ...grpc.CallOption) (*CreateLoadBalancerResponse, error)
+	ImportLoadBalancer(ctx context.Context, in *ImportLoadBalancerRequest, opts ...grpc.CallOption) (*ImportLoadBalancerResponse, error)
+	EnsureLoadBalancer(ctx context.Context, in *EnsureLoadBalancerRequest, opts ...grpc.CallOption) (*EnsureLoadBalancerResponse, error)
+	DeleteLoadBalancer(ctx context.Context, in *DeleteLoadBalancerRequest, opts ...grpc.CallOption) (*DeleteLoadBalancerResponse, error)
+	ValidateBackend(ctx context.Context, in *ValidateBackendRequest, opts ...grpc.CallOption) (*ValidateBackendResponse, error)
+	GenerateBackendAddr(ctx context.Context, in *GenerateBackendAddrRequest, opts ...grpc.CallOption) (*GenerateBackendAddrResponse, error)
+	EnsureBackend(ctx context.Context, in *BackendOperationRequest, opts ...grpc.CallOption) (*BackendOperationResponse, error)
+	DeregisterBackend(ctx context.Context, in *BackendOperationRequest, opts ...grpc.CallOption) (*BackendOperationResponse, error)
+	BatchEnsureBackend(ctx context.Context, in *BatchBackendOperationRequest, opts ...grpc.CallOption) (*BatchBackendOperationResponse, error)
+	BatchDeregisterBackend(ctx context.Context, in *BatchBackendOperationRequest, opts ...grpc.CallOption) (*BatchBackendOperationResponse, error)
+}
+
+type loadBalancerDriverClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLoadBalancerDriverClient(cc grpc.ClientConnInterface) LoadBalancerDriverClient {
+	return &loadBalancerDriverClient{cc}
+}
+
+func (c *loadBalancerDriverClient) ValidateLoadBalancer(ctx context.Context, in *ValidateLoadBalancerRequest, opts ...grpc.CallOption) (*ValidateLoadBalancerResponse, error) {
+	out := new(ValidateLoadBalancerResponse)
+	err := c.cc.Invoke(ctx, LoadBalancerDriver_ValidateLoadBalancer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadBalancerDriverClient) CreateLoadBalancer(ctx context.Context, in *CreateLoadBalancerRequest, opts ...grpc.CallOption) (*CreateLoadBalancerResponse, error) {
+	out := new(CreateLoadBalancerResponse)
+	err := c.cc.Invoke(ctx, LoadBalancerDriver_CreateLoadBalancer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadBalancerDriverClient) ImportLoadBalancer(ctx context.Context, in *ImportLoadBalancerRequest, opts ...grpc.CallOption) (*ImportLoadBalancerResponse, error) {
+	out := new(ImportLoadBalancerResponse)
+	err := c.cc.Invoke(ctx, LoadBalancerDriver_ImportLoadBalancer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadBalancerDriverClient) EnsureLoadBalancer(ctx context.Context, in *EnsureLoadBalancerRequest, opts ...grpc.CallOption) (*EnsureLoadBalancerResponse, error) {
+	out := new(EnsureLoadBalancerResponse)
+	err := c.cc.Invoke(ctx, LoadBalancerDriver_EnsureLoadBalancer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadBalancerDriverClient) DeleteLoadBalancer(ctx context.Context, in *DeleteLoadBalancerRequest, opts ...grpc.CallOption) (*DeleteLoadBalancerResponse, error) {
+	out := new(DeleteLoadBalancerResponse)
+	err := c.cc.Invoke(ctx, LoadBalancerDriver_DeleteLoadBalancer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadBalancerDriverClient) ValidateBackend(ctx context.Context, in *ValidateBackendRequest, opts ...grpc.CallOption) (*ValidateBackendResponse, error) {
+	out := new(ValidateBackendResponse)
+	err := c.cc.Invoke(ctx, LoadBalancerDriver_ValidateBackend_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadBalancerDriverClient) GenerateBackendAddr(ctx context.Context, in *GenerateBackendAddrRequest, opts ...grpc.CallOption) (*GenerateBackendAddrResponse, error) {
+	out := new(GenerateBackendAddrResponse)
+	err := c.cc.Invoke(ctx, LoadBalancerDriver_GenerateBackendAddr_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadBalancerDriverClient) EnsureBackend(ctx context.Context, in *BackendOperationRequest, opts ...grpc.CallOption) (*BackendOperationResponse, error) {
+	out := new(BackendOperationResponse)
+	err := c.cc.Invoke(ctx, LoadBalancerDriver_EnsureBackend_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadBalancerDriverClient) DeregisterBackend(ctx context.Context, in *BackendOperationRequest, opts ...grpc.CallOption) (*BackendOperationResponse, error) {
+	out := new(BackendOperationResponse)
+	err := c.cc.Invoke(ctx, LoadBalancerDriver_DeregisterBackend_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadBalancerDriverClient) BatchEnsureBackend(ctx context.Context, in *BatchBackendOperationRequest, opts ...grpc.CallOption) (*BatchBackendOperationResponse, error) {
+	out := new(BatchBackendOperationResponse)
+	err := c.cc.Invoke(ctx, LoadBalancerDriver_BatchEnsureBackend_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadBalancerDriverClient) BatchDeregisterBackend(ctx context.Context, in *BatchBackendOperationRequest, opts ...grpc.CallOption) (*BatchBackendOperationResponse, error) {
+	out := new(BatchBackendOperationResponse)
+	err := c.cc.Invoke(ctx, LoadBalancerDriver_BatchDeregisterBackend_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LoadBalancerDriverServer is the server API for LoadBalancerDriver service.
+// All implementations must embed UnimplementedLoadBalancerDriverServer
+// for forward compatibility
+type LoadBalancerDriverServer interface {
+	ValidateLoadBalancer(context.Context, *ValidateLoadBalancerRequest) (*ValidateLoadBalancerResponse, error)
+	CreateLoadBalancer(context.Context, *CreateLoadBalancerRequest) (*CreateLoadBalancerResponse, error)
+	ImportLoadBalancer(context.Context, *ImportLoadBalancerRequest) (*ImportLoadBalancerResponse, error)
+	EnsureLoadBalancer(context.Context, *EnsureLoadBalancerRequest) (*EnsureLoadBalancerResponse, error)
+	DeleteLoadBalancer(context.Context, *DeleteLoadBalancerRequest) (*DeleteLoadBalancerResponse, error)
+	ValidateBackend(context.Context, *ValidateBackendRequest) (*ValidateBackendResponse, error)
+	GenerateBackendAddr(context.Context, *GenerateBackendAddrRequest) (*GenerateBackendAddrResponse, error)
+	EnsureBackend(context.Context, *BackendOperationRequest) (*BackendOperationResponse, error)
+	DeregisterBackend(context.Context, *BackendOperationRequest) (*BackendOperationResponse, error)
+	BatchEnsureBackend(context.Context, *BatchBackendOperationRequest) (*BatchBackendOperationResponse, error)
+	BatchDeregisterBackend(context.Context, *BatchBackendOperationRequest) (*BatchBackendOperationResponse, error)
+	mustEmbedUnimplementedLoadBalancerDriverServer()
+}
+
+// UnimplementedLoadBalancerDriverServer must be embedded to have forward compatible implementations.
+type UnimplementedLoadBalancerDriverServer struct {
+}
+
+func (UnimplementedLoadBalancerDriverServer) ValidateLoadBalancer(context.Context, *ValidateLoadBalancerRequest) (*ValidateLoadBalancerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateLoadBalancer not implemented")
+}
+func (UnimplementedLoadBalancerDriverServer) CreateLoadBalancer(context.Context, *CreateLoadBalancerRequest) (*CreateLoadBalancerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLoadBalancer not implemented")
+}
+func (UnimplementedLoadBalancerDriverServer) ImportLoadBalancer(context.Context, *ImportLoadBalancerRequest) (*ImportLoadBalancerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportLoadBalancer not implemented")
+}
+func (UnimplementedLoadBalancerDriverServer) EnsureLoadBalancer(context.Context, *EnsureLoadBalancerRequest) (*EnsureLoadBalancerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnsureLoadBalancer not implemented")
+}
+func (UnimplementedLoadBalancerDriverServer) DeleteLoadBalancer(context.Context, *DeleteLoadBalancerRequest) (*DeleteLoadBalancerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLoadBalancer not implemented")
+}
+func (UnimplementedLoadBalancerDriverServer) ValidateBackend(context.Context, *ValidateBackendRequest) (*ValidateBackendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateBackend not implemented")
+}
+func (UnimplementedLoadBalancerDriverServer) GenerateBackendAddr(context.Context, *GenerateBackendAddrRequest) (*GenerateBackendAddrResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateBackendAddr not implemented")
+}
+func (UnimplementedLoadBalancerDriverServer) EnsureBackend(context.Context, *BackendOperationRequest) (*BackendOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnsureBackend not implemented")
+}
+func (UnimplementedLoadBalancerDriverServer) DeregisterBackend(context.Context, *BackendOperationRequest) (*BackendOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeregisterBackend not implemented")
+}
+func (UnimplementedLoadBalancerDriverServer) BatchEnsureBackend(context.Context, *BatchBackendOperationRequest) (*BatchBackendOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchEnsureBackend not implemented")
+}
+func (UnimplementedLoadBalancerDriverServer) BatchDeregisterBackend(context.Context, *BatchBackendOperationRequest) (*BatchBackendOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeregisterBackend not implemented")
+}
+func (UnimplementedLoadBalancerDriverServer) mustEmbedUnimplementedLoadBalancerDriverServer() {}
+
+// UnsafeLoadBalancerDriverServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LoadBalancerDriverServer will
+// result in compilation errors.
+type UnsafeLoadBalancerDriverServer interface {
+	mustEmbedUnimplementedLoadBalancerDriverServer()
+}
+
+func RegisterLoadBalancerDriverServer(s grpc.ServiceRegistrar, srv LoadBalancerDriverServer) {
+	s.RegisterService(&LoadBalancerDriver_ServiceDesc, srv)
+}
+
+func _LoadBalancerDriver_ValidateLoadBalancer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateLoadBalancerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadBalancerDriverServer).ValidateLoadBalancer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadBalancerDriver_ValidateLoadBalancer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadBalancerDriverServer).ValidateLoadBalancer(ctx, req.(*ValidateLoadBalancerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadBalancerDriver_CreateLoadBalancer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLoadBalancerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadBalancerDriverServer).CreateLoadBalancer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadBalancerDriver_CreateLoadBalancer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadBalancerDriverServer).CreateLoadBalancer(ctx, req.(*CreateLoadBalancerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadBalancerDriver_ImportLoadBalancer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportLoadBalancerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadBalancerDriverServer).ImportLoadBalancer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadBalancerDriver_ImportLoadBalancer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadBalancerDriverServer).ImportLoadBalancer(ctx, req.(*ImportLoadBalancerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadBalancerDriver_EnsureLoadBalancer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnsureLoadBalancerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadBalancerDriverServer).EnsureLoadBalancer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadBalancerDriver_EnsureLoadBalancer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadBalancerDriverServer).EnsureLoadBalancer(ctx, req.(*EnsureLoadBalancerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadBalancerDriver_DeleteLoadBalancer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLoadBalancerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadBalancerDriverServer).DeleteLoadBalancer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadBalancerDriver_DeleteLoadBalancer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadBalancerDriverServer).DeleteLoadBalancer(ctx, req.(*DeleteLoadBalancerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadBalancerDriver_ValidateBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateBackendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadBalancerDriverServer).ValidateBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadBalancerDriver_ValidateBackend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadBalancerDriverServer).ValidateBackend(ctx, req.(*ValidateBackendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadBalancerDriver_GenerateBackendAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateBackendAddrRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadBalancerDriverServer).GenerateBackendAddr(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadBalancerDriver_GenerateBackendAddr_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadBalancerDriverServer).GenerateBackendAddr(ctx, req.(*GenerateBackendAddrRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadBalancerDriver_EnsureBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackendOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadBalancerDriverServer).EnsureBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadBalancerDriver_EnsureBackend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadBalancerDriverServer).EnsureBackend(ctx, req.(*BackendOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadBalancerDriver_DeregisterBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackendOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadBalancerDriverServer).DeregisterBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadBalancerDriver_DeregisterBackend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadBalancerDriverServer).DeregisterBackend(ctx, req.(*BackendOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadBalancerDriver_BatchEnsureBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchBackendOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadBalancerDriverServer).BatchEnsureBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadBalancerDriver_BatchEnsureBackend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadBalancerDriverServer).BatchEnsureBackend(ctx, req.(*BatchBackendOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadBalancerDriver_BatchDeregisterBackend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchBackendOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadBalancerDriverServer).BatchDeregisterBackend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadBalancerDriver_BatchDeregisterBackend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadBalancerDriverServer).BatchDeregisterBackend(ctx, req.(*BatchBackendOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LoadBalancerDriver_ServiceDesc is the grpc.ServiceDesc for LoadBalancerDriver service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LoadBalancerDriver_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lbcf.driver.v1beta1.LoadBalancerDriver",
+	HandlerType: (*LoadBalancerDriverServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ValidateLoadBalancer",
+			Handler:    _LoadBalancerDriver_ValidateLoadBalancer_Handler,
+		},
+		{
+			MethodName: "CreateLoadBalancer",
+			Handler:    _LoadBalancerDriver_CreateLoadBalancer_Handler,
+		},
+		{
+			MethodName: "ImportLoadBalancer",
+			Handler:    _LoadBalancerDriver_ImportLoadBalancer_Handler,
+		},
+		{
+			MethodName: "EnsureLoadBalancer",
+			Handler:    _LoadBalancerDriver_EnsureLoadBalancer_Handler,
+		},
+		{
+			MethodName: "DeleteLoadBalancer",
+			Handler:    _LoadBalancerDriver_DeleteLoadBalancer_Handler,
+		},
+		{
+			MethodName: "ValidateBackend",
+			Handler:    _LoadBalancerDriver_ValidateBackend_Handler,
+		},
+		{
+			MethodName: "GenerateBackendAddr",
+			Handler:    _LoadBalancerDriver_GenerateBackendAddr_Handler,
+		},
+		{
+			MethodName: "EnsureBackend",
+			Handler:    _LoadBalancerDriver_EnsureBackend_Handler,
+		},
+		{
+			MethodName: "DeregisterBackend",
+			Handler:    _LoadBalancerDriver_DeregisterBackend_Handler,
+		},
+		{
+			MethodName: "BatchEnsureBackend",
+			Handler:    _LoadBalancerDriver_BatchEnsureBackend_Handler,
+		},
+		{
+			MethodName: "BatchDeregisterBackend",
+			Handler:    _LoadBalancerDriver_BatchDeregisterBackend_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "driver.proto",
+}
@@ -31,6 +31,9 @@ const (
 	// CreateLoadBalancer is the name and URL path of webhook createLoadBalancer
 	CreateLoadBalancer = "createLoadBalancer"
 
+	// ImportLoadBalancer is the name and URL path of webhook importLoadBalancer
+	ImportLoadBalancer = "importLoadBalancer"
+
 	// EnsureLoadBalancer is the name and URL path of webhook ensureLoadBalancer
 	EnsureLoadBalancer = "ensureLoadBalancer"
 
@@ -48,9 +51,17 @@ const (
 
 	// DeregBackend is the name and URL path of webhook deregisterBackend
 	DeregBackend = "deregisterBackend"
+
+	// BatchEnsureBackend is the name and URL path of webhook batchEnsureBackend
+	BatchEnsureBackend = "batchEnsureBackend"
+
+	// BatchDeregBackend is the name and URL path of webhook batchDeregisterBackend
+	BatchDeregBackend = "batchDeregisterBackend"
 )
 
-// KnownWebhooks is a set contains all supported webhooks
+// KnownWebhooks is a set contains all webhooks that drivers of protocol version v1 must implement.
+//
+// The set is frozen so that existing v1 drivers keep working, webhooks added later must be put in OptionalWebhooks
 var KnownWebhooks = sets.NewString(
 	ValidateLoadBalancer,
 	CreateLoadBalancer,
@@ -62,9 +73,29 @@ var KnownWebhooks = sets.NewString(
 	DeregBackend,
 )
 
+// CoreWebhooks is a set contains all webhooks that drivers of protocol version v2 must implement,
+// other webhooks in KnownWebhooks are optional in v2
+var CoreWebhooks = sets.NewString(
+	CreateLoadBalancer,
+	EnsureLoadBalancer,
+	DeleteLoadBalancer,
+	GenerateBackendAddr,
+	EnsureBackend,
+	DeregBackend,
+)
+
+// OptionalWebhooks is a set contains webhooks that a driver of any protocol version may implement,
+// they are called only if configured in LoadBalancerDriver.spec.webhooks
+var OptionalWebhooks = sets.NewString(
+	ImportLoadBalancer,
+	BatchEnsureBackend,
+	BatchDeregBackend,
+)
+
 // RequestForRetryHooks is the common request for webhooks that can be retried, including:
 //
-// createLoadBalancer, ensureLoadBalancer, deleteLoadBalancer, generateBackendAddr, ensureBackend, deregisterBackend
+// createLoadBalancer, importLoadBalancer, ensureLoadBalancer, deleteLoadBalancer,
+// generateBackendAddr, ensureBackend, deregisterBackend, batchEnsureBackend, batchDeregisterBackend
 type RequestForRetryHooks struct {
 	RecordID string `json:"recordID"`
 	RetryID  string `json:"retryID"`
@@ -72,13 +103,19 @@ type RequestForRetryHooks struct {
 
 // ResponseForFailRetryHooks is the common response for webhooks that can be retried, including:
 //
-// createLoadBalancer, ensureLoadBalancer, deleteLoadBalancer, generateBackendAddr, ensureBackend, deregisterBackend
+// createLoadBalancer, importLoadBalancer, ensureLoadBalancer, deleteLoadBalancer,
+// generateBackendAddr, ensureBackend, deregisterBackend, batchEnsureBackend, batchDeregisterBackend
 type ResponseForFailRetryHooks struct {
 	Status                 string `json:"status"`
 	Msg                    string `json:"msg"`
 	MinRetryDelayInSeconds int32  `json:"minRetryDelayInSeconds"`
 }
 
+// GetStatus returns the status in response
+func (r *ResponseForFailRetryHooks) GetStatus() string {
+	return r.Status
+}
+
 // ResponseForNoRetryHooks is the common response for webhooks that can NOT be retried, including:
 //
 // validateLoadBalancer, validateBackend
@@ -87,6 +124,14 @@ type ResponseForNoRetryHooks struct {
 	Msg  string `json:"msg"`
 }
 
+// GetStatus returns StatusSucc if Succ is true, otherwise StatusFail
+func (r *ResponseForNoRetryHooks) GetStatus() string {
+	if r.Succ {
+		return StatusSucc
+	}
+	return StatusFail
+}
+
 const (
 	// StatusSucc indicates webhook succeeded
 	StatusSucc = "Succ"
@@ -135,6 +180,19 @@ type CreateLoadBalancerResponse struct {
 	LBInfo map[string]string `json:"lbInfo"`
 }
 
+// ImportLoadBalancerRequest is the request for webhook importLoadBalancer
+type ImportLoadBalancerRequest struct {
+	RequestForRetryHooks
+	LBSpec     map[string]string `json:"lbSpec"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// ImportLoadBalancerResponse is the response for webhook importLoadBalancer
+type ImportLoadBalancerResponse struct {
+	ResponseForFailRetryHooks
+	LBInfo map[string]string `json:"lbInfo"`
+}
+
 // EnsureLoadBalancerRequest is the request for webhook ensureLoadBalancer
 type EnsureLoadBalancerRequest struct {
 	RequestForRetryHooks
@@ -181,6 +239,7 @@ type GenerateBackendAddrRequest struct {
 	Parameters     map[string]string                    `json:"parameters"`
 	PodBackend     *PodBackendInGenerateAddrRequest     `json:"podBackend"`
 	ServiceBackend *ServiceBackendInGenerateAddrRequest `json:"serviceBackend"`
+	NodeBackend    *NodeBackendInGenerateAddrRequest    `json:"nodeBackend,omitempty"`
 }
 
 // PodBackendInGenerateAddrRequest is part of GenerateBackendAddrRequest
@@ -197,6 +256,12 @@ type ServiceBackendInGenerateAddrRequest struct {
 	NodeAddresses []v1.NodeAddress     `json:"nodeAddresses"`
 }
 
+// NodeBackendInGenerateAddrRequest is part of GenerateBackendAddrRequest
+type NodeBackendInGenerateAddrRequest struct {
+	Node v1.Node              `json:"node"`
+	Port v1beta1.PortSelector `json:"port"`
+}
+
 // GenerateBackendAddrResponse is the response for webhook generateBackendAddr
 type GenerateBackendAddrResponse struct {
 	ResponseForFailRetryHooks
@@ -210,6 +275,8 @@ type BackendOperationRequest struct {
 	BackendAddr  string            `json:"backendAddr"`
 	Parameters   map[string]string `json:"parameters"`
 	InjectedInfo map[string]string `json:"injectedInfo"`
+	// Weight is only set in ensureBackend when BackendGroup.spec.pods.weight is configured
+	Weight *int32 `json:"weight,omitempty"`
 }
 
 // BackendOperationResponse is the response for webhook ensureBackend and deregisterBackend
@@ -217,3 +284,38 @@ type BackendOperationResponse struct {
 	ResponseForFailRetryHooks
 	InjectedInfo map[string]string `json:"injectedInfo"`
 }
+
+// BatchBackendOperationRequest is the request for webhook batchEnsureBackend and batchDeregisterBackend,
+// all backends in a batch are on the same load balancer
+type BatchBackendOperationRequest struct {
+	RequestForRetryHooks
+	LBInfo   map[string]string           `json:"lbInfo"`
+	Backends []BatchBackendOperationItem `json:"backends"`
+}
+
+// BatchBackendOperationItem is a backend in BatchBackendOperationRequest,
+// RecordID is the same as the one used by ensureBackend and deregisterBackend
+type BatchBackendOperationItem struct {
+	RecordID     string            `json:"recordID"`
+	BackendAddr  string            `json:"backendAddr"`
+	Parameters   map[string]string `json:"parameters"`
+	InjectedInfo map[string]string `json:"injectedInfo"`
+	// Weight is only set in batchEnsureBackend when BackendGroup.spec.pods.weight is configured
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// BatchBackendOperationResponse is the response for webhook batchEnsureBackend and batchDeregisterBackend.
+//
+// If Status is not Succ, the status applies to all backends in the request,
+// otherwise the result of each backend is read from Results
+type BatchBackendOperationResponse struct {
+	ResponseForFailRetryHooks
+	Results []BatchBackendOperationResult `json:"results"`
+}
+
+// BatchBackendOperationResult is the result of a backend in BatchBackendOperationResponse
+type BatchBackendOperationResult struct {
+	ResponseForFailRetryHooks
+	RecordID     string            `json:"recordID"`
+	InjectedInfo map[string]string `json:"injectedInfo"`
+}